@@ -0,0 +1,125 @@
+package flags
+
+import (
+	"os/user"
+	"strconv"
+)
+
+// AccountLookup resolves users and groups for the account flags. It matches the lookup functions of the os/user
+// package so tests can supply fixed accounts.
+type AccountLookup interface {
+	LookupUser(username string) (*user.User, error)
+	LookupUserId(uid string) (*user.User, error)
+	LookupGroup(name string) (*user.Group, error)
+	LookupGroupId(gid string) (*user.Group, error)
+}
+
+type systemAccounts struct{}
+
+func (systemAccounts) LookupUser(username string) (*user.User, error) {
+	return user.Lookup(username)
+}
+
+func (systemAccounts) LookupUserId(uid string) (*user.User, error) {
+	return user.LookupId(uid)
+}
+
+func (systemAccounts) LookupGroup(name string) (*user.Group, error) {
+	return user.LookupGroup(name)
+}
+
+func (systemAccounts) LookupGroupId(gid string) (*user.Group, error) {
+	return user.LookupGroupId(gid)
+}
+
+// SystemAccounts resolves accounts using the os/user package.
+var SystemAccounts AccountLookup = systemAccounts{}
+
+func isNumericId(arg string) bool {
+	_, err := strconv.ParseUint(arg, 10, 32)
+	return err == nil
+}
+
+func userParser(lookup AccountLookup) func(arg string) (*user.User, error) {
+	if lookup == nil {
+		lookup = SystemAccounts
+	}
+
+	return func(arg string) (*user.User, error) {
+		if !isNumericId(arg) {
+			return lookup.LookupUser(arg)
+		}
+
+		account, err := lookup.LookupUserId(arg)
+		if _, unknown := err.(user.UnknownUserIdError); unknown {
+			// Numeric ids don't have to exist on this machine, for example when provisioning containers.
+			return &user.User{Uid: arg}, nil
+		}
+		return account, err
+	}
+}
+
+func groupParser(lookup AccountLookup) func(arg string) (*user.Group, error) {
+	if lookup == nil {
+		lookup = SystemAccounts
+	}
+
+	return func(arg string) (*user.Group, error) {
+		if !isNumericId(arg) {
+			return lookup.LookupGroup(arg)
+		}
+
+		group, err := lookup.LookupGroupId(arg)
+		if _, unknown := err.(user.UnknownGroupIdError); unknown {
+			// Numeric ids don't have to exist on this machine, for example when provisioning containers.
+			return &user.Group{Gid: arg}, nil
+		}
+		return group, err
+	}
+}
+
+// BindUser binds a user account given as either a username or a numeric id. Names are resolved through lookup, which
+// defaults to SystemAccounts when nil. Numeric ids that are unknown to lookup are accepted with only the Uid set.
+func BindUser(name string, description string, optional bool, defaultValue *user.User, lookup AccountLookup) *Binder[*user.User] {
+	return &Binder[*user.User]{
+		flag: &Flag[*user.User]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(userParser(lookup)),
+			formatter: func(account *user.User) string {
+				if account == nil {
+					return ""
+				}
+				if len(account.Username) > 0 {
+					return account.Username
+				}
+				return account.Uid
+			},
+		},
+	}
+}
+
+// BindGroup binds a group given as either a name or a numeric id. Names are resolved through lookup, which defaults
+// to SystemAccounts when nil. Numeric ids that are unknown to lookup are accepted with only the Gid set.
+func BindGroup(name string, description string, optional bool, defaultValue *user.Group, lookup AccountLookup) *Binder[*user.Group] {
+	return &Binder[*user.Group]{
+		flag: &Flag[*user.Group]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(groupParser(lookup)),
+			formatter: func(group *user.Group) string {
+				if group == nil {
+					return ""
+				}
+				if len(group.Name) > 0 {
+					return group.Name
+				}
+				return group.Gid
+			},
+		},
+	}
+}
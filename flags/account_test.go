@@ -0,0 +1,84 @@
+package flags
+
+import (
+	"os/user"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+type testAccounts struct{}
+
+func (testAccounts) LookupUser(username string) (*user.User, error) {
+	if username == "deploy" {
+		return &user.User{Uid: "1001", Gid: "1001", Username: "deploy"}, nil
+	}
+	return nil, user.UnknownUserError(username)
+}
+
+func (testAccounts) LookupUserId(uid string) (*user.User, error) {
+	if uid == "1001" {
+		return &user.User{Uid: "1001", Gid: "1001", Username: "deploy"}, nil
+	}
+	return nil, user.UnknownUserIdError(0)
+}
+
+func (testAccounts) LookupGroup(name string) (*user.Group, error) {
+	if name == "staff" {
+		return &user.Group{Gid: "50", Name: "staff"}, nil
+	}
+	return nil, user.UnknownGroupError(name)
+}
+
+func (testAccounts) LookupGroupId(gid string) (*user.Group, error) {
+	if gid == "50" {
+		return &user.Group{Gid: "50", Name: "staff"}, nil
+	}
+	return nil, user.UnknownGroupIdError(gid)
+}
+
+func TestFlags_User(t *testing.T) {
+	cases := map[string]user.User{
+		"deploy": {Uid: "1001", Gid: "1001", Username: "deploy"},
+		"1001":   {Uid: "1001", Gid: "1001", Username: "deploy"},
+		"2000":   {Uid: "2000"},
+	}
+
+	for arg, expected := range cases {
+		t.Run(arg, func(t *testing.T) {
+			var owner *user.User
+
+			flags := NewSet()
+
+			BindUser("owner", "", false, nil, testAccounts{}).ToValue(flags, &owner)
+
+			args := []string{"--owner", arg}
+			tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+			tests.Execute(*owner).Equal(t, expected)
+		})
+	}
+}
+
+func TestFlags_UserUnknown(t *testing.T) {
+	var owner *user.User
+
+	flags := NewSet()
+
+	BindUser("owner", "", false, nil, testAccounts{}).ToValue(flags, &owner)
+
+	args := []string{"--owner=nobody-here"}
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+func TestFlags_Group(t *testing.T) {
+	var group *user.Group
+
+	flags := NewSet()
+
+	BindGroup("group", "", false, nil, testAccounts{}).ToValue(flags, &group)
+
+	args := []string{"--group=staff"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(*group).Equal(t, user.Group{Gid: "50", Name: "staff"})
+	tests.Execute(flags.Flags["group"].Format(group)).Equal(t, "staff")
+}
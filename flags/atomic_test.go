@@ -0,0 +1,128 @@
+package flags
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_Atomic(t *testing.T) {
+	var name string
+	var count int
+
+	flags := NewSet()
+
+	BindString("name", "", false, "").ToValue(flags, &name)
+	BindInt("count", "", false, 0).ToValue(flags, &count)
+
+	tests.Execute2E(flags.Parse([]string{"--name=web", "--count=x"}, Atomic())).ErrorCode(t, ErrorCodeInvalidValue)
+	tests.Execute(name).Equal(t, "")
+
+	tests.Execute2E(flags.Parse([]string{"--name=web", "--count=2"}, Atomic())).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(name).Equal(t, "web")
+	tests.Execute(count).Equal(t, 2)
+}
+
+func TestFlags_AtomicRules(t *testing.T) {
+	var json, yaml bool
+
+	flags := NewSet()
+
+	BindBoolean("json", "", true, false).ToValue(flags, &json)
+	BindBoolean("yaml", "", true, false).ToValue(flags, &yaml)
+	flags.MutuallyExclusive("json", "yaml")
+
+	tests.Execute2E(flags.Parse([]string{"--json", "--yaml"}, Atomic())).ErrorCode(t, ErrorCodeMutuallyExclusive)
+	tests.Execute(json).Equal(t, false)
+	tests.Execute(yaml).Equal(t, false)
+}
+
+func TestFlags_AtomicRollback(t *testing.T) {
+	alpha, omega := "old", "old"
+
+	flags := NewSet()
+
+	// Values are committed in order of flag name, so alpha is written before the failing flag and omega never is.
+	BindString("alpha", "", false, "").ToValue(flags, &alpha)
+	BindString("middle", "", false, "").ToFunction(flags, func(_ string, value string) error {
+		return fmt.Errorf("rejected %q", value)
+	})
+	BindString("omega", "", false, "").ToValue(flags, &omega)
+
+	args := []string{"--alpha=new", "--middle=x", "--omega=new"}
+	tests.Execute2E(flags.Parse(args, Atomic())).ErrorCode(t, ErrorCodeInvalidValue)
+	tests.Execute(alpha).Equal(t, "old")
+	tests.Execute(omega).Equal(t, "old")
+
+	// Without Atomic every other target is still written.
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+	tests.Execute(alpha).Equal(t, "new")
+	tests.Execute(omega).Equal(t, "new")
+}
+
+func TestFlags_AtomicOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "existing.txt")
+	tests.ExecuteE(os.WriteFile(path, []byte("precious"), 0o644)).NoError(t)
+
+	var out *os.File
+
+	flags := NewSet()
+	defer flags.Close()
+
+	BindOutputFile("out", "", false, nil, PathOptions{}).ToValue(flags, &out)
+	BindInt("n", "", false, 0).ToValue(flags, new(int))
+
+	tests.Execute2E(flags.Parse([]string{"--out", path, "--n=x"}, Atomic())).ErrorCode(t, ErrorCodeInvalidValue)
+	tests.Execute(out == nil).Equal(t, true)
+
+	contents := tests.Execute2E(os.ReadFile(path)).NoError(t).Capture()
+	tests.Execute(string(contents)).Equal(t, "precious")
+
+	tests.Execute2E(flags.Parse([]string{"--out", path, "--n=1"}, Atomic())).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(out.Name()).Equal(t, path)
+}
+
+func TestFlags_AtomicOutputFileDirectory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "existing.txt")
+	tests.ExecuteE(os.WriteFile(path, []byte("precious"), 0o644)).NoError(t)
+
+	flags := NewSet()
+	defer flags.Close()
+
+	BindOutputFile("a", "", false, nil, PathOptions{}).ToValue(flags, new(*os.File))
+	BindOutputFile("b", "", false, nil, PathOptions{}).ToValue(flags, new(*os.File))
+
+	// The directory is rejected while parsing, before the first file is truncated.
+	tests.Execute2E(flags.Parse([]string{"--a", path, "--b", dir}, Atomic())).ErrorCode(t, ErrorCodeInvalidValue)
+
+	contents := tests.Execute2E(os.ReadFile(path)).NoError(t).Capture()
+	tests.Execute(string(contents)).Equal(t, "precious")
+}
+
+func TestFlags_AtomicRollbackStruct(t *testing.T) {
+	opts := struct {
+		Level slog.Level `flag:"level"`
+		Name  string     `flag:"name"`
+	}{Level: slog.LevelInfo, Name: "old"}
+
+	flags := NewSet()
+
+	BindStruct(flags, &opts)
+	BindString("omega", "", false, "").ToFunction(flags, func(_ string, value string) error {
+		return fmt.Errorf("rejected %q", value)
+	})
+
+	// Both struct fields, including the text field, are written before omega fails and then restored.
+	args := []string{"--level=debug", "--name=new", "--omega=x"}
+	tests.Execute2E(flags.Parse(args, Atomic())).ErrorCode(t, ErrorCodeInvalidValue)
+	tests.Execute(opts.Level).Equal(t, slog.LevelInfo)
+	tests.Execute(opts.Name).Equal(t, "old")
+
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+	tests.Execute(opts.Level).Equal(t, slog.LevelDebug)
+}
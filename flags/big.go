@@ -0,0 +1,83 @@
+package flags
+
+import (
+	"fmt"
+	"math/big"
+)
+
+func bigIntParser(arg string) (*big.Int, error) {
+	normalized, base, ok := normalizeInteger(arg)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", arg)
+	}
+
+	value, ok := new(big.Int).SetString(normalized, base)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", arg)
+	}
+	return value, nil
+}
+
+func bigFloatParser(prec uint) func(arg string) (*big.Float, error) {
+	return func(arg string) (*big.Float, error) {
+		value, _, err := big.ParseFloat(arg, 0, prec, big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// BindBigInt binds an arbitrary precision integer flag. Values accept the same base prefixes and digit separators as
+// the built-in integer flags.
+func BindBigInt(name string, description string, optional bool, defaultValue *big.Int) *Binder[*big.Int] {
+	return &Binder[*big.Int]{
+		flag: &Flag[*big.Int]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(bigIntParser),
+		},
+	}
+}
+
+// BindBigIntSlice is the repeated version of BindBigInt.
+func BindBigIntSlice(name string, description string, optional bool, defaultValue []*big.Int) *Binder[[]*big.Int] {
+	return &Binder[[]*big.Int]{
+		flag: &Flag[[]*big.Int]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Slice(Scalar(bigIntParser)),
+		},
+	}
+}
+
+// BindBigFloat binds an arbitrary precision floating point flag. Parsed values are rounded to prec bits of mantissa,
+// or 64 bits if prec is 0.
+func BindBigFloat(name string, description string, optional bool, defaultValue *big.Float, prec uint) *Binder[*big.Float] {
+	return &Binder[*big.Float]{
+		flag: &Flag[*big.Float]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(bigFloatParser(prec)),
+		},
+	}
+}
+
+// BindBigFloatSlice is the repeated version of BindBigFloat.
+func BindBigFloatSlice(name string, description string, optional bool, defaultValue []*big.Float, prec uint) *Binder[[]*big.Float] {
+	return &Binder[[]*big.Float]{
+		flag: &Flag[[]*big.Float]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Slice(Scalar(bigFloatParser(prec))),
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package flags
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_BigInt(t *testing.T) {
+	var value *big.Int
+
+	flags := NewSet()
+
+	BindBigInt("value", "", false, nil).ToValue(flags, &value)
+
+	args := []string{"--value=0xffffffffffffffffffffffffffffffff"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(value.String()).Equal(t, "340282366920938463463374607431768211455")
+}
+
+func TestFlags_BigIntInvalid(t *testing.T) {
+	var value *big.Int
+
+	flags := NewSet()
+
+	BindBigInt("value", "", false, nil).ToValue(flags, &value)
+
+	args := []string{"--value=12ab"}
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+func TestFlags_BigFloat(t *testing.T) {
+	var value *big.Float
+
+	flags := NewSet()
+
+	BindBigFloat("value", "", false, nil, 256).ToValue(flags, &value)
+
+	args := []string{"--value=1_000.000000000000000000000001"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(value.Text('f', 24)).Equal(t, "1000.000000000000000000000001")
+	tests.Execute(value.Prec()).Equal(t, uint(256))
+}
@@ -0,0 +1,106 @@
+package flags
+
+import "fmt"
+
+// Number matches the numeric types supported by the built-in flags.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+// Integral matches the integer types supported by the built-in flags.
+type Integral interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Constraint rejects values that a flag should not accept. Constraints are attached to a flag with Binder.Constrain.
+type Constraint[T any] func(value T) error
+
+// isNaN reports whether value is NaN, the only value that isn't equal to itself. Every comparison with NaN is false, so
+// the bounds reject it explicitly rather than letting it pass.
+func isNaN[T Number](value T) bool {
+	return value != value
+}
+
+// Min requires values to be greater than or equal to lower.
+func Min[T Number](lower T) Constraint[T] {
+	return func(value T) error {
+		if isNaN(value) || value < lower {
+			return fmt.Errorf("%v is out of range, must be at least %v", value, lower)
+		}
+		return nil
+	}
+}
+
+// Max requires values to be less than or equal to upper.
+func Max[T Number](upper T) Constraint[T] {
+	return func(value T) error {
+		if isNaN(value) || value > upper {
+			return fmt.Errorf("%v is out of range, must be at most %v", value, upper)
+		}
+		return nil
+	}
+}
+
+// GreaterThan requires values to be strictly greater than lower.
+func GreaterThan[T Number](lower T) Constraint[T] {
+	return func(value T) error {
+		if isNaN(value) || value <= lower {
+			return fmt.Errorf("%v is out of range, must be greater than %v", value, lower)
+		}
+		return nil
+	}
+}
+
+// LessThan requires values to be strictly less than upper.
+func LessThan[T Number](upper T) Constraint[T] {
+	return func(value T) error {
+		if isNaN(value) || value >= upper {
+			return fmt.Errorf("%v is out of range, must be less than %v", value, upper)
+		}
+		return nil
+	}
+}
+
+// Between requires values to be within lower and upper inclusive.
+func Between[T Number](lower T, upper T) Constraint[T] {
+	return func(value T) error {
+		if isNaN(value) || value < lower || value > upper {
+			return fmt.Errorf("%v is out of range, must be between %v and %v", value, lower, upper)
+		}
+		return nil
+	}
+}
+
+// MultipleOf requires values to be an exact multiple of n.
+func MultipleOf[T Integral](n T) Constraint[T] {
+	return func(value T) error {
+		if n != 0 && value%n != 0 {
+			return fmt.Errorf("%v must be a multiple of %v", value, n)
+		}
+		return nil
+	}
+}
+
+// NonZero rejects the zero value.
+func NonZero[T Number]() Constraint[T] {
+	return func(value T) error {
+		if value == 0 {
+			return fmt.Errorf("value must not be zero")
+		}
+		return nil
+	}
+}
+
+// Each applies constraints to every element of a slice, for use with the repeated flag binders.
+func Each[T any](constraints ...Constraint[T]) Constraint[[]T] {
+	return func(values []T) error {
+		for _, value := range values {
+			for _, constraint := range constraints {
+				if err := constraint(value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,105 @@
+package flags
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_Constrain(t *testing.T) {
+	var port uint16
+
+	flags := NewSet()
+
+	BindUint16("port", "", false, 0).Constrain(NonZero[uint16]()).ToValue(flags, &port)
+
+	tests.Execute2E(flags.Parse([]string{"--port=8080"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(port).Equal(t, uint16(8080))
+
+	tests.Execute2E(flags.Parse([]string{"--port=0"})).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+func TestFlags_ConstrainMessage(t *testing.T) {
+	var workers int
+
+	flags := NewSet()
+
+	BindInt("workers", "", false, 0).Constrain(Between(1, 64)).ToValue(flags, &workers)
+
+	tests.Execute2E(flags.Parse([]string{"--workers=100"})).Validate(t, func(err error) {
+		if !strings.Contains(err.Error(), "100 is out of range, must be between 1 and 64") {
+			t.Errorf("expected the allowed range in the error, got %q", err.Error())
+		}
+	})
+}
+
+func TestFlags_Constraints(t *testing.T) {
+	cases := map[string]struct {
+		constraint Constraint[float64]
+		valid      []float64
+		invalid    []float64
+	}{
+		"min":          {Min(1.0), []float64{1, 2}, []float64{0.5, math.NaN()}},
+		"max":          {Max(1.0), []float64{0, 1}, []float64{1.5, math.NaN()}},
+		"greater than": {GreaterThan(1.0), []float64{1.5}, []float64{1, math.NaN()}},
+		"less than":    {LessThan(1.0), []float64{0.5}, []float64{1, math.NaN()}},
+		"between":      {Between(0.0, 1.0), []float64{0, 0.5, 1}, []float64{-1, 2, math.NaN()}},
+		"non zero":     {NonZero[float64](), []float64{-1, 1}, []float64{0}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, value := range tc.valid {
+				tests.ExecuteE(tc.constraint(value)).NoError(t)
+			}
+			for _, value := range tc.invalid {
+				tests.ExecuteE(tc.constraint(value)).Error(t)
+			}
+		})
+	}
+}
+
+func TestFlags_ConstrainNaN(t *testing.T) {
+	var ratio float64
+
+	flags := NewSet()
+
+	BindFloat64("ratio", "", false, 0).Constrain(Between(0.0, 1.0)).ToValue(flags, &ratio)
+
+	tests.Execute2E(flags.Parse([]string{"--ratio=0.5"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--ratio=NaN"})).ErrorCode(t, ErrorCodeInvalidValue)
+	tests.Execute(ratio).Equal(t, 0.5)
+}
+
+func TestFlags_ConstrainSlice(t *testing.T) {
+	var sizes []int
+
+	flags := NewSet()
+
+	BindIntSlice("size", "", false, nil).Constrain(Each(MultipleOf(4), Min(4))).ToValue(flags, &sizes)
+
+	tests.Execute2E(flags.Parse([]string{"--size=4", "--size=16"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(sizes).Equal(t, []int{4, 16})
+
+	tests.Execute2E(flags.Parse([]string{"--size=4", "--size=6"})).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+func TestFlags_ConstrainBoolean(t *testing.T) {
+	var confirm bool
+
+	mustConfirm := func(value bool) error {
+		if !value {
+			return errors.New("confirmation is required")
+		}
+		return nil
+	}
+
+	flags := NewSet()
+
+	BindBoolean("confirm", "", false, false).Constrain(mustConfirm).ToValue(flags, &confirm)
+
+	tests.Execute2E(flags.Parse([]string{"--no-confirm"})).ErrorCode(t, ErrorCodeInvalidValue)
+}
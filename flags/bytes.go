@@ -0,0 +1,94 @@
+package flags
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// BytesEncoding selects how byte slice flags are encoded on the command line.
+type BytesEncoding int
+
+const (
+	// BytesEncodingHex decodes hexadecimal strings, with an optional 0x prefix.
+	BytesEncodingHex BytesEncoding = iota
+
+	// BytesEncodingBase64 decodes standard base64, with or without padding.
+	BytesEncodingBase64
+
+	// BytesEncodingBase64URL decodes URL-safe base64, with or without padding.
+	BytesEncodingBase64URL
+
+	// BytesEncodingRaw uses the bytes of the value as is.
+	BytesEncodingRaw
+)
+
+func (encoding BytesEncoding) decode(arg string) ([]byte, error) {
+	switch encoding {
+	case BytesEncodingHex:
+		if digits, ok := strings.CutPrefix(arg, "0x"); ok {
+			arg = digits
+		}
+		return hex.DecodeString(arg)
+	case BytesEncodingBase64:
+		if len(arg)%4 == 0 {
+			return base64.StdEncoding.DecodeString(arg)
+		}
+		return base64.RawStdEncoding.DecodeString(arg)
+	case BytesEncodingBase64URL:
+		if len(arg)%4 == 0 {
+			return base64.URLEncoding.DecodeString(arg)
+		}
+		return base64.RawURLEncoding.DecodeString(arg)
+	case BytesEncodingRaw:
+		return []byte(arg), nil
+	}
+	return nil, fmt.Errorf("unknown bytes encoding %d", encoding)
+}
+
+func (encoding BytesEncoding) encode(value []byte) string {
+	switch encoding {
+	case BytesEncodingHex:
+		return hex.EncodeToString(value)
+	case BytesEncodingBase64:
+		return base64.StdEncoding.EncodeToString(value)
+	case BytesEncodingBase64URL:
+		return base64.URLEncoding.EncodeToString(value)
+	}
+	return string(value)
+}
+
+// BindBytes binds a byte slice flag, decoding the value with encoding.
+func BindBytes(name string, description string, optional bool, defaultValue []byte, encoding BytesEncoding) *Binder[[]byte] {
+	return &Binder[[]byte]{
+		flag: &Flag[[]byte]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(encoding.decode),
+			formatter:   encoding.encode,
+		},
+	}
+}
+
+// BindBytesSlice is the repeated version of BindBytes.
+func BindBytesSlice(name string, description string, optional bool, defaultValue [][]byte, encoding BytesEncoding) *Binder[[][]byte] {
+	return &Binder[[][]byte]{
+		flag: &Flag[[][]byte]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Slice(Scalar(encoding.decode)),
+			formatter: func(values [][]byte) string {
+				encoded := make([]string, 0, len(values))
+				for _, value := range values {
+					encoded = append(encoded, encoding.encode(value))
+				}
+				return fmt.Sprint(encoded)
+			},
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_Bytes(t *testing.T) {
+	cases := map[string]struct {
+		encoding BytesEncoding
+		arg      string
+	}{
+		"hex":             {BytesEncodingHex, "deadbeef"},
+		"hex prefix":      {BytesEncodingHex, "0xDEADBEEF"},
+		"base64":          {BytesEncodingBase64, "3q2+7w=="},
+		"base64 unpadded": {BytesEncodingBase64, "3q2+7w"},
+		"base64url":       {BytesEncodingBase64URL, "3q2-7w=="},
+		"base64url raw":   {BytesEncodingBase64URL, "3q2-7w"},
+		"raw":             {BytesEncodingRaw, "\xde\xad\xbe\xef"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var value []byte
+
+			flags := NewSet()
+
+			BindBytes("key", "", false, nil, tc.encoding).ToValue(flags, &value)
+
+			args := []string{"--key", tc.arg}
+			tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+			tests.Execute(value).Equal(t, []byte{0xde, 0xad, 0xbe, 0xef})
+		})
+	}
+}
+
+func TestFlags_BytesInvalid(t *testing.T) {
+	var value []byte
+
+	flags := NewSet()
+
+	BindBytes("key", "", false, nil, BytesEncodingHex).ToValue(flags, &value)
+
+	args := []string{"--key=xyz"}
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+func TestFlags_BytesSlice(t *testing.T) {
+	var values [][]byte
+
+	flags := NewSet()
+
+	BindBytesSlice("salt", "", true, [][]byte{{0x01}}, BytesEncodingBase64).ToValue(flags, &values)
+
+	tests.Execute(flags.Flags["salt"].FormatDefault()).Equal(t, "[AQ==]")
+
+	args := []string{"--salt=AQI=", "--salt=AwQ"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(values).Equal(t, [][]byte{{0x01, 0x02}, {0x03, 0x04}})
+}
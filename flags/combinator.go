@@ -0,0 +1,105 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/pasataleo/go-errors/errors"
+)
+
+// Scalar builds a parser for a flag that must be given exactly once, converting the value with fn.
+func Scalar[T any](fn func(arg string) (T, error)) Parser[T] {
+	return &singleArgParser[T]{
+		parser: fn,
+	}
+}
+
+// Slice lifts a parser into one that accepts the flag any number of times, passing each value to parser in turn.
+func Slice[T any](parser Parser[T]) Parser[[]T] {
+	return ParserFn[[]T](func(name string, args []string) ([]T, error) {
+		if len(args) == 0 {
+			return nil, errors.Newf(nil, ErrorCodeMissingFlag, "missing flag %q", name)
+		}
+
+		var result []T
+		for _, arg := range args {
+			value, err := parser.Parse(name, []string{arg})
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+		}
+		return result, nil
+	})
+}
+
+// Map converts the output of parser into another type using fn.
+func Map[T any, U any](parser Parser[T], fn func(value T) (U, error)) Parser[U] {
+	return ParserFn[U](func(name string, args []string) (U, error) {
+		var errorResult U
+
+		value, err := parser.Parse(name, args)
+		if err != nil {
+			return errorResult, err
+		}
+
+		result, err := fn(value)
+		if err != nil {
+			return errorResult, errors.Newf(err, ErrorCodeInvalidValue, "invalid value for flag %q", name)
+		}
+		return result, nil
+	})
+}
+
+// Validate runs each validator, in order, against the output of parser. The first validator to fail rejects the
+// value. Validate works at the level of parsers, such as checking each element of a Slice, and so never sees default
+// values. To check the value of a flag use Binder.Validate, or Binder.Constrain to skip defaults.
+func Validate[T any](parser Parser[T], validators ...func(value T) error) Parser[T] {
+	return ParserFn[T](func(name string, args []string) (T, error) {
+		var errorResult T
+
+		value, err := parser.Parse(name, args)
+		if err != nil {
+			return errorResult, err
+		}
+
+		for _, validator := range validators {
+			if err := validator(value); err != nil {
+				return errorResult, errors.Newf(err, ErrorCodeInvalidValue, "invalid value for flag %q", name)
+			}
+		}
+		return value, nil
+	})
+}
+
+// OneOf tries each parser in turn and returns the result of the first one that succeeds.
+func OneOf[T any](parsers ...Parser[T]) Parser[T] {
+	return ParserFn[T](func(name string, args []string) (T, error) {
+		var errorResult T
+
+		var errs error
+		for _, parser := range parsers {
+			value, err := parser.Parse(name, args)
+			if err == nil {
+				return value, nil
+			}
+
+			if errors.Is(err, ErrorCodeMissingFlag) || errors.Is(err, ErrorCodeDuplicateFlag) {
+				return errorResult, err
+			}
+			errs = errors.Append(errs, err)
+		}
+		return errorResult, errors.Newf(errs, ErrorCodeInvalidValue, "invalid value for flag %q", name)
+	})
+}
+
+// Keyword builds a parser that only accepts the exact keyword, producing value. It is typically combined with other
+// parsers using OneOf.
+func Keyword[T any](keyword string, value T) Parser[T] {
+	return Scalar(func(arg string) (T, error) {
+		if arg != keyword {
+			var errorResult T
+			return errorResult, fmt.Errorf("expected %q", keyword)
+		}
+		return value, nil
+	})
+}
@@ -0,0 +1,75 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_CombinatorScalar(t *testing.T) {
+	var value string
+
+	flags := NewSet()
+
+	BindValue("value", "", false, "", Scalar(func(arg string) (string, error) {
+		return strings.ToUpper(arg), nil
+	})).ToValue(flags, &value)
+
+	args := []string{"--value=hello"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(value).Equal(t, "HELLO")
+}
+
+func TestFlags_CombinatorSlice(t *testing.T) {
+	var values []int
+
+	flags := NewSet()
+
+	BindValue("value", "", false, nil, Slice(Integer[int]())).ToValue(flags, &values)
+
+	args := []string{"--value=1", "--value=0x10"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(values).Equal(t, []int{1, 16})
+}
+
+func TestFlags_CombinatorMapAndValidate(t *testing.T) {
+	var value string
+
+	positive := func(value int) error {
+		if value <= 0 {
+			return fmt.Errorf("must be positive")
+		}
+		return nil
+	}
+
+	parser := Map(Validate(Integer[int](), positive), func(value int) (string, error) {
+		return strings.Repeat("x", value), nil
+	})
+
+	flags := NewSet()
+
+	BindValue("value", "", false, "", parser).ToValue(flags, &value)
+
+	tests.Execute2E(flags.Parse([]string{"--value=3"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(value).Equal(t, "xxx")
+
+	tests.Execute2E(flags.Parse([]string{"--value=-3"})).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+func TestFlags_CombinatorOneOf(t *testing.T) {
+	var value int
+
+	flags := NewSet()
+
+	BindValue("workers", "", false, 0, OneOf(Integer[int](), Keyword("auto", -1))).ToValue(flags, &value)
+
+	tests.Execute2E(flags.Parse([]string{"--workers=auto"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(value).Equal(t, -1)
+
+	tests.Execute2E(flags.Parse([]string{"--workers=4"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(value).Equal(t, 4)
+
+	tests.Execute2E(flags.Parse([]string{"--workers=many"})).ErrorCode(t, ErrorCodeInvalidValue)
+}
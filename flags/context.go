@@ -8,6 +8,9 @@ import (
 
 type Binder[T any] struct {
 	flag *Flag[T]
+
+	// err records a mistake in how the flag was configured, which is returned when the flag is bound to a Set.
+	err error
 }
 
 type TargetFn[T any] func(name string, value T) error
@@ -40,7 +43,52 @@ func (binder *Binder[T]) ToFunction(flags *Set, target TargetFn[T]) {
 	}
 }
 
+// Sensitive marks the flag as containing sensitive data. Its values are redacted from errors and formatted output.
+func (binder *Binder[T]) Sensitive() *Binder[T] {
+	binder.flag.sensitive = true
+	binder.flag.formatter = func(T) string {
+		return redacted
+	}
+	return binder
+}
+
+// Constrain rejects values given for the flag that don't satisfy every constraint. Default values are not checked, so
+// a default outside the constraints, such as zero for an optional port, can still mean "unset". Constrain is otherwise
+// the same as Validate.
+func (binder *Binder[T]) Constrain(constraints ...Constraint[T]) *Binder[T] {
+	name := binder.flag.Name
+	for _, constraint := range constraints {
+		binder.addValidator(true, func(value T) error {
+			if err := constraint(value); err != nil {
+				return errors.Newf(err, ErrorCodeInvalidValue, "invalid value for flag %q", name)
+			}
+			return nil
+		})
+	}
+	return binder
+}
+
+// Validate adds validators that run on the value of the flag before it is written to the target or passed to the
+// TargetFn. Unlike Constrain, validators also run against the default value.
+func (binder *Binder[T]) Validate(validators ...func(value T) error) *Binder[T] {
+	for _, validator := range validators {
+		binder.addValidator(false, validator)
+	}
+	return binder
+}
+
+// addValidator adds check to the validators of the flag, which run in the order they were added.
+func (binder *Binder[T]) addValidator(skipDefaults bool, check func(value T) error) {
+	binder.flag.validators = append(binder.flag.validators, validator[T]{
+		check:        check,
+		skipDefaults: skipDefaults,
+	})
+}
+
 func (binder *Binder[T]) setFlag(flags *Set) error {
+	if binder.err != nil {
+		return binder.err
+	}
 	if _, exists := flags.Flags[binder.flag.Name]; exists {
 		return errors.Newf(nil, ErrorCodeDuplicateFlag, "duplicate flag %q", binder.flag.Name)
 	}
@@ -0,0 +1,241 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField describes the allowed values of a single field within a cron expression.
+type cronField struct {
+	name  string
+	lower int
+	upper int
+	names map[string]int
+}
+
+var (
+	cronMinute     = cronField{name: "minute", lower: 0, upper: 59}
+	cronHour       = cronField{name: "hour", lower: 0, upper: 23}
+	cronDayOfMonth = cronField{name: "day of month", lower: 1, upper: 31}
+	cronMonth      = cronField{name: "month", lower: 1, upper: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	cronDayOfWeek = cronField{name: "day of week", lower: 0, upper: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// CronSchedule is a schedule parsed from a standard 5 field cron expression: minute, hour, day of month, month and
+// day of week.
+type CronSchedule struct {
+	text string
+
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+
+	// When both day fields are restricted a time matches if either of them does, following the behaviour of cron.
+	dayOfMonthRestricted bool
+	dayOfWeekRestricted  bool
+}
+
+// ParseCronSchedule parses a standard 5 field cron expression, such as "*/15 9-17 * * mon-fri", or one of the macros
+// @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly.
+func ParseCronSchedule(expression string) (CronSchedule, error) {
+	schedule := CronSchedule{
+		text: expression,
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) == 1 && strings.HasPrefix(fields[0], "@") {
+		macro, ok := cronMacros[strings.ToLower(fields[0])]
+		if !ok {
+			return CronSchedule{}, fmt.Errorf("unknown cron macro %q", fields[0])
+		}
+		fields = strings.Fields(macro)
+	}
+
+	if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("invalid cron expression %q: expected 5 fields, found %d", expression, len(fields))
+	}
+
+	var err error
+	if schedule.minute, err = cronMinute.parse(fields[0]); err != nil {
+		return CronSchedule{}, err
+	}
+	if schedule.hour, err = cronHour.parse(fields[1]); err != nil {
+		return CronSchedule{}, err
+	}
+	if schedule.dayOfMonth, err = cronDayOfMonth.parse(fields[2]); err != nil {
+		return CronSchedule{}, err
+	}
+	if schedule.month, err = cronMonth.parse(fields[3]); err != nil {
+		return CronSchedule{}, err
+	}
+	if schedule.dayOfWeek, err = cronDayOfWeek.parse(fields[4]); err != nil {
+		return CronSchedule{}, err
+	}
+
+	// Sunday can be written as either 0 or 7.
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+
+	schedule.dayOfMonthRestricted = !strings.HasPrefix(fields[2], "*")
+	schedule.dayOfWeekRestricted = !strings.HasPrefix(fields[4], "*")
+	return schedule, nil
+}
+
+// parse converts a single cron field into a bit set of the values it matches.
+func (field cronField) parse(expression string) (uint64, error) {
+	fail := func(format string, args ...any) (uint64, error) {
+		return 0, fmt.Errorf("invalid %s field %q: %s", field.name, expression, fmt.Sprintf(format, args...))
+	}
+
+	var bits uint64
+	for _, item := range strings.Split(expression, ",") {
+		spec, stepText, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step <= 0 {
+				return fail("invalid step %q", stepText)
+			}
+		}
+
+		var start, end int
+		switch {
+		case spec == "*":
+			start, end = field.lower, field.upper
+		case strings.Contains(spec, "-"):
+			startText, endText, _ := strings.Cut(spec, "-")
+
+			var err error
+			if start, err = field.value(startText); err != nil {
+				return fail("%s", err)
+			}
+			if end, err = field.value(endText); err != nil {
+				return fail("%s", err)
+			}
+			if start > end {
+				return fail("range %q is reversed", spec)
+			}
+		default:
+			var err error
+			if start, err = field.value(spec); err != nil {
+				return fail("%s", err)
+			}
+
+			// A single value with a step, such as 5/15, runs from that value to the end of the field.
+			end = start
+			if hasStep {
+				end = field.upper
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+func (field cronField) value(text string) (int, error) {
+	if value, ok := field.names[strings.ToLower(text)]; ok {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", text)
+	}
+	if value < field.lower || value > field.upper {
+		return 0, fmt.Errorf("value %d is out of range, must be between %d and %d", value, field.lower, field.upper)
+	}
+	return value, nil
+}
+
+func (schedule CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := schedule.dayOfMonth&(1<<t.Day()) != 0
+	dayOfWeek := schedule.dayOfWeek&(1<<int(t.Weekday())) != 0
+
+	switch {
+	case schedule.dayOfMonthRestricted && schedule.dayOfWeekRestricted:
+		return dayOfMonth || dayOfWeek
+	case schedule.dayOfMonthRestricted:
+		return dayOfMonth
+	case schedule.dayOfWeekRestricted:
+		return dayOfWeek
+	}
+	return true
+}
+
+// Next returns the first time after the given time that matches the schedule, in the location of after. The zero
+// time is returned if the schedule doesn't match any time within the next five years, for example for the 30th of
+// February.
+func (schedule CronSchedule) Next(after time.Time) time.Time {
+	if schedule.minute == 0 {
+		return time.Time{}
+	}
+
+	location := after.Location()
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute(), 0, 0, location).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if schedule.month&(1<<int(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location)
+			continue
+		}
+
+		if !schedule.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location)
+			continue
+		}
+
+		if schedule.hour&(1<<t.Hour()) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, location)
+			continue
+		}
+
+		if schedule.minute&(1<<t.Minute()) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (schedule CronSchedule) String() string {
+	return schedule.text
+}
+
+// BindCronSchedule binds a cron schedule flag, see ParseCronSchedule for the supported syntax.
+func BindCronSchedule(name string, description string, optional bool, defaultValue CronSchedule) *Binder[CronSchedule] {
+	return &Binder[CronSchedule]{
+		flag: &Flag[CronSchedule]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(ParseCronSchedule),
+		},
+	}
+}
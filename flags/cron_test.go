@@ -0,0 +1,80 @@
+package flags
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_CronSchedule(t *testing.T) {
+	after := time.Date(2024, time.January, 15, 10, 30, 45, 0, time.UTC) // Monday
+
+	cases := map[string]time.Time{
+		"*/15 * * * *":         time.Date(2024, time.January, 15, 10, 45, 0, 0, time.UTC),
+		"0 9-17 * * mon-fri":   time.Date(2024, time.January, 15, 11, 0, 0, 0, time.UTC),
+		"0 0 * * 7":            time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC),
+		"30 6 1 feb *":         time.Date(2024, time.February, 1, 6, 30, 0, 0, time.UTC),
+		"0 0 29 2 *":           time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"0 12 1 * wed":         time.Date(2024, time.January, 17, 12, 0, 0, 0, time.UTC),
+		"5,35 10 * * *":        time.Date(2024, time.January, 15, 10, 35, 0, 0, time.UTC),
+		"@hourly":              time.Date(2024, time.January, 15, 11, 0, 0, 0, time.UTC),
+		"@daily":               time.Date(2024, time.January, 16, 0, 0, 0, 0, time.UTC),
+		"@yearly":              time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"@weekly":              time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC),
+		"10/20 * 15 jan 1-5/2": time.Date(2024, time.January, 15, 10, 50, 0, 0, time.UTC),
+	}
+
+	for arg, expected := range cases {
+		t.Run(arg, func(t *testing.T) {
+			var schedule CronSchedule
+
+			flags := NewSet()
+
+			BindCronSchedule("schedule", "", false, CronSchedule{}).ToValue(flags, &schedule)
+
+			args := []string{"--schedule", arg}
+			tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+			tests.Execute(schedule.Next(after)).Equal(t, expected)
+			tests.Execute(schedule.String()).Equal(t, arg)
+		})
+	}
+}
+
+func TestFlags_CronScheduleNever(t *testing.T) {
+	schedule := tests.Execute2E(ParseCronSchedule("0 0 30 feb *")).NoError(t).Capture()
+	tests.Execute(schedule.Next(time.Now()).IsZero()).Equal(t, true)
+}
+
+func TestFlags_CronScheduleInvalid(t *testing.T) {
+	cases := map[string]string{
+		"* * * *":      "expected 5 fields",
+		"60 * * * *":   "invalid minute field",
+		"* 24 * * *":   "invalid hour field",
+		"* * 0 * *":    "invalid day of month field",
+		"* * * foo *":  "invalid month field",
+		"* * * * 8":    "invalid day of week field",
+		"*/0 * * * *":  "invalid minute field",
+		"* 5-2 * * *":  "invalid hour field",
+		"@fortnightly": "unknown cron macro",
+	}
+
+	for arg, message := range cases {
+		t.Run(arg, func(t *testing.T) {
+			var schedule CronSchedule
+
+			flags := NewSet()
+
+			BindCronSchedule("schedule", "", false, CronSchedule{}).ToValue(flags, &schedule)
+
+			args := []string{"--schedule", arg}
+			tests.Execute2E(flags.Parse(args)).Validate(t, func(err error) {
+				if !errors.Is(err, ErrorCodeInvalidValue) || !strings.Contains(err.Error(), message) {
+					t.Errorf("expected %q to contain %q", err.Error(), message)
+				}
+			})
+		})
+	}
+}
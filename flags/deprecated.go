@@ -0,0 +1,85 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pasataleo/go-errors/errors"
+)
+
+// Deprecated marks the flag as deprecated. Using it writes a warning containing message to Set.Warnings, and the flag
+// is hidden from help output. If replacement names another flag, values given for this flag are forwarded to it, and
+// giving both flags is an error. Deprecated flags are always optional.
+func (binder *Binder[T]) Deprecated(message string, replacement string) *Binder[T] {
+	binder.flag.Deprecated = message
+	binder.flag.Replacement = replacement
+	binder.flag.Hidden = true
+	binder.flag.Optional = true
+	return binder
+}
+
+// warn writes a warning to w.
+func warn(w io.Writer, format string, args ...any) {
+	_, _ = fmt.Fprintf(w, "warning: "+format+"\n", args...)
+}
+
+// forwardDeprecated warns about every deprecated flag that was given, and moves their values to their replacements.
+func (flags *Set) forwardDeprecated(warnings io.Writer, unparsed map[string]map[string][]string, occurrences map[string]*occurrence, state *Values) error {
+	var deprecated []string
+	for name := range unparsed {
+		if flag, exists := flags.Flags[name]; exists && len(flag.Deprecated) > 0 {
+			deprecated = append(deprecated, name)
+		}
+	}
+
+	// Warn in the order the flags appeared on the command line.
+	sort.Slice(deprecated, func(i, j int) bool {
+		return occurrences[deprecated[i]].index < occurrences[deprecated[j]].index
+	})
+
+	var err error
+	for _, name := range deprecated {
+		flag := flags.Flags[name]
+
+		replacement, exists := flags.Flags[flag.Replacement]
+		if !exists {
+			warn(warnings, "flag %q is deprecated: %s", name, flag.Deprecated)
+			continue
+		}
+		warn(warnings, "flag %q is deprecated, use %q instead: %s", name, replacement.Name, flag.Deprecated)
+
+		if _, given := unparsed[replacement.Name]; given {
+			err = errors.Append(err, newParseError(name, occurrences[name], errors.Newf(nil, ErrorCodeMutuallyExclusive, "flags %q and %q are mutually exclusive, %q is deprecated in favour of %q", name, replacement.Name, name, replacement.Name)))
+			delete(unparsed, name)
+			continue
+		}
+
+		forwarded := make(map[string][]string)
+		for alias, values := range unparsed[name] {
+			forwarded[flags.forwardAlias(flag, replacement, alias)] = values
+		}
+		unparsed[replacement.Name] = forwarded
+		occurrences[replacement.Name] = occurrences[name]
+		state.given[replacement.Name] = true
+		delete(unparsed, name)
+	}
+	return err
+}
+
+// forwardAlias maps an alias of a deprecated flag onto the equivalent alias of its replacement, such as "no-old" to
+// "no-new", falling back to the name of the replacement.
+func (flags *Set) forwardAlias(flag *Flag[any], replacement *Flag[any], alias string) string {
+	if alias == flag.Name {
+		return replacement.Name
+	}
+
+	candidate := strings.Replace(alias, flag.Name, replacement.Name, 1)
+	for _, replacementAlias := range replacement.Aliases {
+		if candidate == replacementAlias {
+			return candidate
+		}
+	}
+	return replacement.Name
+}
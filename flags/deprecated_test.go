@@ -0,0 +1,55 @@
+package flags
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_Deprecated(t *testing.T) {
+	var warnings bytes.Buffer
+	var legacy, current string
+
+	flags := NewSet()
+	flags.Warnings = &warnings
+
+	BindString("out", "", true, "").Deprecated("renamed in v2", "output").ToValue(flags, &legacy)
+	BindString("output", "", true, "stdout").ToValue(flags, &current)
+
+	tests.Execute2E(flags.Parse([]string{"--out", "file.txt"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(current).Equal(t, "file.txt")
+	tests.Execute(legacy).Equal(t, "")
+	tests.Execute(warnings.String()).Equal(t, "warning: flag \"out\" is deprecated, use \"output\" instead: renamed in v2\n")
+	tests.Execute(flags.Flags["out"].Hidden).Equal(t, true)
+}
+
+func TestFlags_DeprecatedAlias(t *testing.T) {
+	var warnings bytes.Buffer
+	var colour bool
+
+	flags := NewSet()
+	flags.Warnings = &warnings
+
+	BindBoolean("color", "", true, false).Deprecated("use British spelling", "colour").ToValue(flags, new(bool))
+	BindBoolean("colour", "", true, true).ToValue(flags, &colour)
+
+	tests.Execute2E(flags.Parse([]string{"--no-color"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(colour).Equal(t, false)
+}
+
+func TestFlags_DeprecatedConflict(t *testing.T) {
+	var warnings bytes.Buffer
+
+	flags := NewSet()
+	flags.Warnings = &warnings
+
+	BindString("out", "", true, "").Deprecated("renamed in v2", "output").ToValue(flags, new(string))
+	BindString("output", "", true, "").ToValue(flags, new(string))
+	BindString("other", "", true, "").ToValue(flags, new(string))
+
+	tests.Execute2E(flags.Parse([]string{"--out=a", "--output=b"})).ErrorCode(t, ErrorCodeMutuallyExclusive)
+
+	// Deprecated flags are never suggested.
+	tests.Execute(flags.suggest("ou")).Equal(t, []string{"output"})
+}
@@ -0,0 +1,87 @@
+package flags
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_Resolve(t *testing.T) {
+	var name string
+	var calls int
+
+	flags := NewSet()
+
+	BindString("name", "", false, "").ToValue(flags, &name)
+	BindInt("count", "", true, 3).ToFunction(flags, func(string, int) error {
+		calls++
+		return nil
+	})
+
+	values := tests.Execute2E(flags.Resolve([]string{"--name=web"})).NoError(t).Capture()
+	tests.Execute(name).Equal(t, "")
+	tests.Execute(calls).Equal(t, 0)
+
+	resolved, ok := values.Get("name")
+	tests.Execute(ok).Equal(t, true)
+	tests.Execute(resolved).Equal(t, any("web"))
+	tests.Execute(values.Given("name")).Equal(t, true)
+
+	resolved, _ = values.Get("count")
+	tests.Execute(resolved).Equal(t, any(3))
+	tests.Execute(values.Given("count")).Equal(t, false)
+}
+
+func TestFlags_ResolveErrors(t *testing.T) {
+	var count int
+
+	flags := NewSet()
+
+	BindInt("count", "", false, 0).ToValue(flags, &count)
+	BindString("name", "", true, "").ToValue(flags, new(string))
+	flags.AtLeastOneOf("name")
+
+	tests.Execute2E(flags.Resolve([]string{"--count=x"})).Validate(t, func(err error) {
+		tests.Execute(len(ParseErrors(err))).Equal(t, 2)
+	})
+	tests.Execute2E(flags.Parse([]string{"--count=5", "--name=a"}, DryRun())).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(count).Equal(t, 0)
+}
+
+func TestFlags_ResolveOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+
+	flags := NewSet()
+	defer flags.Close()
+
+	BindOutputFile("out", "", false, nil, PathOptions{}).ToValue(flags, new(*os.File))
+
+	tests.Execute2E(flags.Resolve([]string{"--out", path})).NoError(t)
+
+	_, err := os.Stat(path)
+	tests.Execute(os.IsNotExist(err)).Equal(t, true)
+}
+
+func TestFlags_ResolveStdin(t *testing.T) {
+	var config map[string]int
+	var file *os.File
+
+	flags := NewSet()
+	defer flags.Close()
+
+	BindJSON("config", "", false, map[string]int(nil), false).ToValue(flags, &config)
+	BindFile("input", "", true, nil, PathOptions{}).ToValue(flags, &file)
+
+	stdin := WithStdin(strings.NewReader(`{"replicas": 3}`))
+
+	// Resolve reads stdin, but a following Parse with the same reader still sees everything.
+	args := []string{"--config=@-", "--input=-"}
+	tests.Execute2E(flags.Resolve(args, stdin)).NoError(t)
+	tests.Execute2E(flags.Parse(args, stdin)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(config).Equal(t, map[string]int{"replicas": 3})
+	tests.Execute2E(io.ReadAll(file)).NoError(t).Equal(t, []byte(`{"replicas": 3}`))
+}
@@ -7,4 +7,11 @@ const (
 	ErrorCodeUnknownFlag   errors.ErrorCode = "flags.ErrorCodeUnknownFlag"
 	ErrorCodeDuplicateFlag errors.ErrorCode = "flags.ErrorCodeDuplicateFlag"
 	ErrorCodeInvalidValue  errors.ErrorCode = "flags.ErrorCodeInvalidValue"
+
+	ErrorCodeUnsupportedType   errors.ErrorCode = "flags.ErrorCodeUnsupportedType"
+	ErrorCodeMutuallyExclusive errors.ErrorCode = "flags.ErrorCodeMutuallyExclusive"
+
+	// ErrorCodeInvalidRule reports a rule, such as MutuallyExclusive, that names a flag that was never bound. Unlike
+	// ErrorCodeUnknownFlag it is a mistake in the program rather than in its arguments.
+	ErrorCodeInvalidRule errors.ErrorCode = "flags.ErrorCodeInvalidRule"
 )
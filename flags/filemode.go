@@ -0,0 +1,137 @@
+package flags
+
+import (
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// parseFileMode parses either an octal mode, such as 0644 or 0o4755, or a symbolic mode, such as u=rw,g=r. Symbolic
+// modes are applied to an empty mode, so only their set bits are present in the result.
+func parseFileMode(arg string) (fs.FileMode, error) {
+	if len(arg) == 0 {
+		return 0, fmt.Errorf("empty file mode")
+	}
+
+	if arg[0] >= '0' && arg[0] <= '9' {
+		return parseOctalMode(arg)
+	}
+	return parseSymbolicMode(arg)
+}
+
+func parseOctalMode(arg string) (fs.FileMode, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(arg, "0o"), "0O")
+	value, err := strconv.ParseUint(digits, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid octal file mode %q", arg)
+	}
+	if value > 07777 {
+		return 0, fmt.Errorf("invalid octal file mode %q: out of range", arg)
+	}
+
+	mode := fs.FileMode(value & 0777)
+	if value&04000 != 0 {
+		mode |= fs.ModeSetuid
+	}
+	if value&02000 != 0 {
+		mode |= fs.ModeSetgid
+	}
+	if value&01000 != 0 {
+		mode |= fs.ModeSticky
+	}
+	return mode, nil
+}
+
+func parseSymbolicMode(arg string) (fs.FileMode, error) {
+	var mode fs.FileMode
+	for _, clause := range strings.Split(arg, ",") {
+		// Work out which classes of user the clause refers to, defaulting to all of them.
+		var who fs.FileMode
+		ix := 0
+		for ; ix < len(clause) && strings.IndexByte("ugoa", clause[ix]) >= 0; ix++ {
+			switch clause[ix] {
+			case 'u':
+				who |= 0700 | fs.ModeSetuid
+			case 'g':
+				who |= 0070 | fs.ModeSetgid
+			case 'o':
+				who |= 0007 | fs.ModeSticky
+			case 'a':
+				who |= 0777 | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky
+			}
+		}
+		if who == 0 {
+			who = 0777 | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky
+		}
+
+		if ix == len(clause) {
+			return 0, fmt.Errorf("invalid symbolic file mode %q: missing operator in %q", arg, clause)
+		}
+
+		for ix < len(clause) {
+			op := clause[ix]
+			if op != '+' && op != '-' && op != '=' {
+				return 0, fmt.Errorf("invalid symbolic file mode %q: unexpected %q in %q", arg, op, clause)
+			}
+			ix++
+
+			var perms fs.FileMode
+			for ; ix < len(clause) && strings.IndexByte("+-=", clause[ix]) < 0; ix++ {
+				switch clause[ix] {
+				case 'r':
+					perms |= 0444
+				case 'w':
+					perms |= 0222
+				case 'x':
+					perms |= 0111
+				case 's':
+					perms |= fs.ModeSetuid | fs.ModeSetgid
+				case 't':
+					perms |= fs.ModeSticky
+				default:
+					return 0, fmt.Errorf("invalid symbolic file mode %q: unknown permission %q in %q", arg, clause[ix], clause)
+				}
+			}
+			perms &= who
+
+			switch op {
+			case '+':
+				mode |= perms
+			case '-':
+				mode &^= perms
+			case '=':
+				mode = (mode &^ who) | perms
+			}
+		}
+	}
+	return mode, nil
+}
+
+func formatFileMode(mode fs.FileMode) string {
+	value := uint32(mode.Perm())
+	if mode&fs.ModeSetuid != 0 {
+		value |= 04000
+	}
+	if mode&fs.ModeSetgid != 0 {
+		value |= 02000
+	}
+	if mode&fs.ModeSticky != 0 {
+		value |= 01000
+	}
+	return fmt.Sprintf("%04o", value)
+}
+
+// BindFileMode binds a file permission flag accepting octal modes, such as 0644, or symbolic modes, such as u=rw,g=r.
+func BindFileMode(name string, description string, optional bool, defaultValue fs.FileMode) *Binder[fs.FileMode] {
+	return &Binder[fs.FileMode]{
+		flag: &Flag[fs.FileMode]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(parseFileMode),
+			formatter:   formatFileMode,
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package flags
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_FileMode(t *testing.T) {
+	cases := map[string]fs.FileMode{
+		"0644":        0644,
+		"755":         0755,
+		"0o600":       0600,
+		"4755":        fs.ModeSetuid | 0755,
+		"1777":        fs.ModeSticky | 0777,
+		"u=rw,g=r":    0640,
+		"a=r,u+w":     0644,
+		"u=rwx,go=rx": 0755,
+		"=rw,o-w":     0664,
+		"u=rwx,u+s":   fs.ModeSetuid | 0700,
+		"+t,a+rwx":    fs.ModeSticky | 0777,
+	}
+
+	for arg, expected := range cases {
+		t.Run(arg, func(t *testing.T) {
+			var mode fs.FileMode
+
+			flags := NewSet()
+
+			BindFileMode("mode", "", false, 0).ToValue(flags, &mode)
+
+			args := []string{"--mode", arg}
+			tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+			tests.Execute(mode).Equal(t, expected)
+		})
+	}
+}
+
+func TestFlags_FileModeInvalid(t *testing.T) {
+	for _, arg := range []string{"0888", "17777", "u", "u=rwz", "q=r"} {
+		var mode fs.FileMode
+
+		flags := NewSet()
+
+		BindFileMode("mode", "", false, 0).ToValue(flags, &mode)
+
+		args := []string{"--mode", arg}
+		tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+	}
+}
+
+func TestFlags_FileModeFormat(t *testing.T) {
+	flags := NewSet()
+
+	BindFileMode("mode", "", true, fs.ModeSetgid|0750).ToFunction(flags, func(string, fs.FileMode) error { return nil })
+
+	tests.Execute(flags.Flags["mode"].FormatDefault()).Equal(t, "2750")
+}
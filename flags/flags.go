@@ -2,25 +2,30 @@ package flags
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/pasataleo/go-errors/errors"
 )
 
-type ParseBehavior int
-
-const (
-	// ParseBehaviorStrict will make the Parse function return an error if any of the flags are unknown.
-	ParseBehaviorStrict = iota
-
-	// ParseBehaviorReadOnly is used to parse flags without modifying the underlying arguments.
-	ParseBehaviorReadOnly
-)
-
 type Set struct {
 	Flags   map[string]*Flag[any]
 	aliases map[string]string
+
+	// closers release resources, such as open files, created while parsing flags.
+	closers []func() error
+
+	// rules check relationships between flags once every flag has been parsed.
+	rules []rule
+
+	// stdin buffers what was read from stdin by previous calls to Parse and Resolve.
+	stdin *stdinBuffer
+
+	// Warnings receives warnings, such as the use of deprecated flags, produced while parsing. Defaults to stderr.
+	Warnings io.Writer
 }
 
 func NewSet() *Set {
@@ -30,31 +35,48 @@ func NewSet() *Set {
 	}
 }
 
-func (flags *Set) Parse(args []string, behaviours ...ParseBehavior) ([]string, error) {
-	var strict, readOnly bool
-	for _, behaviour := range behaviours {
-		switch behaviour {
-		case ParseBehaviorStrict:
-			strict = true
-		case ParseBehaviorReadOnly:
-			readOnly = true
+// Close releases any resources, such as open files, that were acquired while parsing flags.
+func (flags *Set) Close() error {
+	var err error
+	for _, closer := range flags.closers {
+		if closeErr := closer(); closeErr != nil {
+			err = errors.Append(err, closeErr)
 		}
 	}
+	flags.closers = nil
+	return err
+}
+
+// Parse parses the flags within args, writing their values to the bound targets, and returns the arguments that
+// weren't consumed.
+func (flags *Set) Parse(args []string, options ...ParseOption) ([]string, error) {
+	remaining, _, err := flags.parse(args, newParseOptions(options))
+	return remaining, err
+}
 
-	return flags.parse(args, strict, readOnly)
+// Resolve runs the full parse and validation pipeline against args without writing to any targets or calling any
+// TargetFn, and returns the values every flag resolved to. Output files are not created, but files opened for reading,
+// such as by BindFile, are opened and must still be released with Close. Anything read from stdin is kept by the Set,
+// so a following Parse with the same stdin reads the same data again.
+func (flags *Set) Resolve(args []string, options ...ParseOption) (*Values, error) {
+	opts := newParseOptions(options)
+	opts.dryRun = true
+
+	_, values, err := flags.parse(args, opts)
+	return values, err
 }
 
-func (flags *Set) parse(args []string, strict bool, readOnly bool) ([]string, error) {
+func (flags *Set) parse(args []string, opts *parseOptions) ([]string, *Values, error) {
 	var err error
 
 	// Anything we don't process will be returned.
 	remaining := make([]string, 0, len(args))
-	if readOnly {
+	if opts.readOnly {
 		remaining = args
 	}
 
 	appendRemaining := func(arg string) {
-		if readOnly {
+		if opts.readOnly {
 			return
 		}
 		remaining = append(remaining, arg)
@@ -65,6 +87,11 @@ func (flags *Set) parse(args []string, strict bool, readOnly bool) ([]string, er
 	unparsed := make(map[string]map[string][]string)
 
 	isFlagName := func(arg string) (string, bool) {
+		// A lone dash is conventionally a value referring to stdin or stdout.
+		if arg == "-" {
+			return arg, false
+		}
+
 		if name, ok := strings.CutPrefix(arg, "--"); ok {
 			return name, true
 		}
@@ -90,6 +117,24 @@ func (flags *Set) parse(args []string, strict bool, readOnly bool) ([]string, er
 		return name, name
 	}
 
+	// occurrences records where each flag first appeared in args, so errors can point at the offending argument.
+	occurrences := make(map[string]*occurrence)
+
+	record := func(name string, alias string, index int, value string) {
+		if _, exists := unparsed[name]; !exists {
+			unparsed[name] = make(map[string][]string)
+		}
+		unparsed[name][alias] = append(unparsed[name][alias], value)
+
+		if _, exists := occurrences[name]; !exists {
+			occurrences[name] = &occurrence{
+				alias: alias,
+				index: index,
+			}
+		}
+		occurrences[name].values = append(occurrences[name].values, value)
+	}
+
 	skipNextArg := false
 	for ix, arg := range args {
 		if skipNextArg {
@@ -107,55 +152,96 @@ func (flags *Set) parse(args []string, strict bool, readOnly bool) ([]string, er
 		name, alias := resolveName(name)
 
 		if _, exists := flags.Flags[name]; !exists {
-			if strict {
-				err = errors.Append(err, errors.Newf(nil, ErrorCodeUnknownFlag, "unknown flag %q", name))
+			if opts.strict {
+				// Values of unknown flags are never recorded, as they may be a mistyped sensitive flag.
+				unknown := &occurrence{alias: alias, index: ix}
+				suggestions := flags.suggest(name)
+
+				message := fmt.Sprintf("unknown flag %q", name)
+				if len(suggestions) > 0 {
+					message = fmt.Sprintf("%s, did you mean %s?", message, quoteNames(suggestions, "or"))
+				}
+
+				parseErr := newParseError(name, unknown, errors.Newf(nil, ErrorCodeUnknownFlag, "%s", message))
+				parseErr.Suggestions = suggestions
+				err = errors.Append(err, parseErr)
 			}
 			appendRemaining(arg)
 			continue
 		}
 
 		if containsValue {
-			if _, exists := unparsed[name]; !exists {
-				unparsed[name] = make(map[string][]string)
-			}
-			unparsed[name][alias] = append(unparsed[name][alias], value)
+			record(name, alias, ix, value)
 			continue
 		}
 
 		if ix+1 < len(args) {
 			nextArg := args[ix+1]
 			if _, isFlag := isFlagName(nextArg); !isFlag {
-				if _, exists := unparsed[name]; !exists {
-					unparsed[name] = make(map[string][]string)
-				}
-				unparsed[name][alias] = append(unparsed[name][alias], nextArg)
+				record(name, alias, ix, nextArg)
 				skipNextArg = true
 				continue
 			}
 		}
 
-		if _, exists := unparsed[name]; !exists {
-			unparsed[name] = make(map[string][]string)
-		}
-		unparsed[name][alias] = append(unparsed[name][alias], "")
+		record(name, alias, ix, "")
+	}
+
+	state := &Values{
+		given:       make(map[string]bool, len(unparsed)),
+		resolved:    make(map[string]any, len(flags.Flags)),
+		occurrences: make(map[string]*occurrence, len(unparsed)),
+	}
+	for name := range unparsed {
+		state.given[name] = true
+	}
+
+	warnings := opts.warnings
+	if warnings == nil {
+		warnings = flags.Warnings
+	}
+	if warnings == nil {
+		warnings = os.Stderr
 	}
 
+	if forwardErr := flags.forwardDeprecated(warnings, unparsed, occurrences, state); forwardErr != nil {
+		err = errors.Append(err, forwardErr)
+	}
+
+	// origins records where the values that were parsed, rather than defaulted, came from.
+	origins := make(map[string]*occurrence)
+
 	for name, flag := range flags.Flags {
 		if _, exists := unparsed[name]; !exists {
 			if !flag.Optional {
-				err = errors.Append(err, errors.Newf(nil, ErrorCodeMissingFlag, "missing flag %q", name))
+				err = errors.Append(err, newParseError(name, nil, errors.Newf(nil, ErrorCodeMissingFlag, "missing flag %q", name)))
 				continue
 			}
 
-			if valueErr := flag.setValue(flag.Default); valueErr != nil {
-				err = errors.Append(err, errors.Newf(valueErr, ErrorCodeInvalidValue, "could not set default value for %q", name))
+			if valueErr := flag.validate(flag.Default, true); valueErr != nil {
+				err = errors.Append(err, newParseError(name, nil, errors.Newf(flag.redact(valueErr), ErrorCodeInvalidValue, "invalid default value for %q", name)))
+				continue
 			}
+
+			state.resolved[name] = flag.Default
 			continue
 		}
 
 		values := unparsed[name]
 		delete(unparsed, name)
 
+		if flag.parseContext != nil {
+			*flag.parseContext = parseContext{
+				stdin: flags.stdinFor(opts.stdin),
+			}
+		}
+
+		occurrence := occurrences[name]
+		if flag.sensitive {
+			occurrence = occurrence.redacted()
+		}
+		state.occurrences[name] = occurrence
+
 		if flag.parser != nil {
 			var flattened []string
 			for _, value := range values {
@@ -164,28 +250,36 @@ func (flags *Set) parse(args []string, strict bool, readOnly bool) ([]string, er
 
 			value, valueErr := flag.parser.Parse(name, flattened)
 			if valueErr != nil {
-				err = errors.Append(err, errors.Newf(valueErr, ErrorCodeInvalidValue, "invalid flag %q", name))
+				err = errors.Append(err, newParseError(name, occurrence, errors.Newf(flag.redact(valueErr), ErrorCodeInvalidValue, "invalid flag %q", name)))
 				continue
 			}
+			flags.release(flag, value)
 
-			if valueErr := flag.setValue(value); valueErr != nil {
-				err = errors.Append(err, errors.Newf(valueErr, ErrorCodeInvalidValue, "invalid flag %q", name))
+			if valueErr := flag.validate(value, false); valueErr != nil {
+				err = errors.Append(err, newParseError(name, occurrence, errors.Newf(flag.redact(valueErr), ErrorCodeInvalidValue, "invalid flag %q", name)))
+				continue
 			}
 
+			state.resolved[name] = value
+			origins[name] = occurrence
 			continue
 		}
 
 		if flag.aliasParser != nil {
 			value, valueErr := flag.aliasParser.Parse(name, values)
 			if valueErr != nil {
-				err = errors.Append(err, errors.Newf(valueErr, ErrorCodeInvalidValue, "invalid flag %q", name))
+				err = errors.Append(err, newParseError(name, occurrence, errors.Newf(flag.redact(valueErr), ErrorCodeInvalidValue, "invalid flag %q", name)))
 				continue
 			}
+			flags.release(flag, value)
 
-			if valueErr := flag.setValue(value); valueErr != nil {
-				err = errors.Append(err, errors.Newf(valueErr, ErrorCodeInvalidValue, "invalid flag %q", name))
+			if valueErr := flag.validate(value, false); valueErr != nil {
+				err = errors.Append(err, newParseError(name, occurrence, errors.Newf(flag.redact(valueErr), ErrorCodeInvalidValue, "invalid flag %q", name)))
+				continue
 			}
 
+			state.resolved[name] = value
+			origins[name] = occurrence
 			continue
 		}
 
@@ -193,7 +287,109 @@ func (flags *Set) parse(args []string, strict bool, readOnly bool) ([]string, er
 		panic("flag doesn't have a parser")
 	}
 
-	return remaining, err
+	// Atomic parses only commit once every rule has been checked, and only if nothing failed.
+	if !opts.dryRun && !opts.atomic {
+		if commitErr := flags.commit(state, origins, false); commitErr != nil {
+			err = errors.Append(err, commitErr)
+		}
+	}
+
+	for _, rule := range flags.rules {
+		if ruleErr := rule(state); ruleErr != nil {
+			err = errors.Append(err, ruleErr)
+		}
+	}
+
+	if !opts.dryRun && opts.atomic && err == nil {
+		err = flags.commit(state, origins, true)
+	}
+
+	return remaining, state, err
+}
+
+// commit acquires any resources for the resolved values and writes them to the targets of their flags, in order of flag
+// name. If atomic is set, commit stops at the first failure and restores the targets it has already written. Values
+// already passed to a TargetFn, and resources already acquired, can't be restored.
+func (flags *Set) commit(state *Values, origins map[string]*occurrence, atomic bool) error {
+	names := make([]string, 0, len(state.resolved))
+	for name := range state.resolved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var err error
+
+	// Resources, such as output files, are only acquired once values are committed, so a failed or dry run parse
+	// never creates them. Acquire everything before writing any target, so an atomic parse can still give up cleanly.
+	for _, name := range names {
+		flag := flags.Flags[name]
+		if _, parsed := origins[name]; !parsed || flag.acquire == nil {
+			continue
+		}
+
+		value, valueErr := flag.acquire(state.resolved[name])
+		if valueErr != nil {
+			err = errors.Append(err, newParseError(name, origins[name], errors.Newf(flag.redact(valueErr), ErrorCodeInvalidValue, "invalid flag %q", name)))
+			delete(state.resolved, name)
+			continue
+		}
+		flags.release(flag, value)
+		state.resolved[name] = value
+	}
+	if atomic && err != nil {
+		return err
+	}
+
+	type snapshot struct {
+		target   reflect.Value
+		previous reflect.Value
+	}
+
+	// previous holds the values of targets written so far, so they can be restored.
+	var previous []snapshot
+
+	for _, name := range names {
+		flag := flags.Flags[name]
+		value, resolved := state.resolved[name]
+		if !resolved {
+			continue
+		}
+
+		if atomic && flag.target.IsValid() {
+			value := reflect.New(flag.target.Type()).Elem()
+			value.Set(flag.target)
+			previous = append(previous, snapshot{target: flag.target, previous: value})
+		}
+
+		valueErr := flag.setValue(value)
+		if valueErr == nil {
+			continue
+		}
+
+		if origin, parsed := origins[name]; parsed {
+			err = errors.Append(err, newParseError(name, origin, errors.Newf(flag.redact(valueErr), ErrorCodeInvalidValue, "invalid flag %q", name)))
+		} else {
+			err = errors.Append(err, newParseError(name, nil, errors.Newf(flag.redact(valueErr), ErrorCodeInvalidValue, "could not set default value for %q", name)))
+		}
+
+		if atomic {
+			for ix := len(previous) - 1; ix >= 0; ix-- {
+				previous[ix].target.Set(previous[ix].previous)
+			}
+			return err
+		}
+	}
+	return err
+}
+
+// release registers the value parsed for flag to be closed by Close, if the flag manages a resource.
+func (flags *Set) release(flag *Flag[any], value any) {
+	if flag.closer == nil {
+		return
+	}
+	flags.closers = append(flags.closers, func() error {
+		return flag.closer(value)
+	})
 }
 
 type Flag[T any] struct {
@@ -203,9 +399,37 @@ type Flag[T any] struct {
 	Optional    bool
 	Description string
 
+	// Deprecated explains why the flag is deprecated, and is empty for flags that aren't.
+	Deprecated string
+
+	// Replacement names the flag that values given for a deprecated flag are forwarded to.
+	Replacement string
+
+	// Hidden flags should be left out of help output.
+	Hidden bool
+
 	parser      Parser[T]
 	aliasParser aliasParser[T]
 
+	// formatter converts values of this flag back into text, falling back to fmt.Sprint when nil.
+	formatter func(value T) string
+
+	// acquire, if set, opens any resource, such as an output file, for a parsed value just before it is written to the
+	// target. Parsers of such flags only validate their arguments, so dry runs and failed parses have no side effects.
+	acquire func(value T) (T, error)
+
+	// closer releases any resource held by a value produced by the parser of this flag.
+	closer func(value T) error
+
+	// sensitive flags never include their values in errors or formatted output.
+	sensitive bool
+
+	// parseContext, if set, is shared with the parser and filled in with the options of each call to Parse.
+	parseContext *parseContext
+
+	// validators check values before they are written to the target, added by Binder.Validate and Binder.Constrain.
+	validators []validator[T]
+
 	targetFn TargetFn[T]
 
 	// target is used for injecting the flag value directly into a value.
@@ -221,6 +445,52 @@ func (f *Flag[T]) setValue(value T) error {
 	return nil
 }
 
+// validator checks the value of a flag. Validators that skip defaults only check values given to Parse.
+type validator[T any] struct {
+	check        func(value T) error
+	skipDefaults bool
+}
+
+// validate runs the validators of this flag against value, returning the first error. isDefault is set when value is
+// the default of the flag, rather than a parsed value.
+func (f *Flag[T]) validate(value T, isDefault bool) error {
+	for _, validator := range f.validators {
+		if isDefault && validator.skipDefaults {
+			continue
+		}
+		if err := validator.check(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Format returns the textual representation of the given value for this flag.
+func (f *Flag[T]) Format(value T) string {
+	if f.formatter != nil {
+		return f.formatter(value)
+	}
+	return fmt.Sprint(value)
+}
+
+// redact strips the details, which may contain the raw value, from errors produced by sensitive flags.
+func (f *Flag[T]) redact(err error) error {
+	if !f.sensitive || err == nil {
+		return err
+	}
+	return errors.Newf(nil, errors.GetErrorCode(err), "invalid value for flag %q", f.Name)
+}
+
+// FormatDefault returns the textual representation of the default value for this flag.
+func (f *Flag[T]) FormatDefault() string {
+	return f.Format(f.Default)
+}
+
+// String describes the flag and its default value for debugging.
+func (f *Flag[T]) String() string {
+	return fmt.Sprintf("--%s=%s", f.Name, f.FormatDefault())
+}
+
 func (f *Flag[T]) generic() *Flag[interface{}] {
 	generic := &Flag[interface{}]{
 		Name:        f.Name,
@@ -228,10 +498,42 @@ func (f *Flag[T]) generic() *Flag[interface{}] {
 		Default:     f.Default,
 		Optional:    f.Optional,
 		Description: f.Description,
+		Deprecated:  f.Deprecated,
+		Replacement: f.Replacement,
+		Hidden:      f.Hidden,
 		targetFn: func(_ string, i interface{}) error {
 			return f.setValue(i.(T))
 		},
-		target: f.target,
+		target:       f.target,
+		sensitive:    f.sensitive,
+		parseContext: f.parseContext,
+	}
+
+	if f.formatter != nil {
+		generic.formatter = func(i interface{}) string {
+			return f.formatter(i.(T))
+		}
+	}
+
+	for _, original := range f.validators {
+		generic.validators = append(generic.validators, validator[any]{
+			check: func(i interface{}) error {
+				return original.check(i.(T))
+			},
+			skipDefaults: original.skipDefaults,
+		})
+	}
+
+	if f.acquire != nil {
+		generic.acquire = func(i interface{}) (interface{}, error) {
+			return f.acquire(i.(T))
+		}
+	}
+
+	if f.closer != nil {
+		generic.closer = func(i interface{}) error {
+			return f.closer(i.(T))
+		}
 	}
 
 	if f.parser != nil {
@@ -169,3 +169,29 @@ func TestFlags_ParseToInjector(t *testing.T) {
 	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
 	tests.Execute(value).Equal(t, "hello")
 }
+
+func TestFlags_IntegerBasePrefixes(t *testing.T) {
+	var values []int64
+	var mode uint32
+
+	flags := NewSet()
+
+	BindInt64Slice("value", "", false, nil).ToValue(flags, &values)
+	BindUint32("mode", "", false, 0).ToValue(flags, &mode)
+
+	args := []string{"--value=0x1F", "--value=-0b1010", "--value=1_000_000", "--value=010", "--mode=0o755"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(values).Equal(t, []int64{31, -10, 1000000, 10})
+	tests.Execute(mode).Equal(t, uint32(0755))
+}
+
+func TestFlags_IntegerInvalidUnderscores(t *testing.T) {
+	var number int
+
+	flags := NewSet()
+
+	BindInt("number", "", false, 0).ToValue(flags, &number)
+
+	args := []string{"-number=1__000"}
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+}
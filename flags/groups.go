@@ -0,0 +1,227 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pasataleo/go-errors/errors"
+)
+
+// rule checks a relationship between several flags after parsing. Rules may be added before the flags they name are
+// bound, so the names are only checked against the registered flags once Parse runs. Rules naming a flag that doesn't
+// exist fail with ErrorCodeInvalidRule, rather than silently never matching.
+type rule func(values *Values) error
+
+// MutuallyExclusive makes Parse fail if more than one of the named flags is given on the command line. Flags that only
+// receive their default value never conflict.
+func (flags *Set) MutuallyExclusive(names ...string) {
+	flags.rules = append(flags.rules, func(values *Values) error {
+		if err := flags.unknownOf(names...); err != nil {
+			return err
+		}
+
+		conflicts := values.givenOf(names)
+		if len(conflicts) < 2 {
+			return nil
+		}
+		return newRuleError(values, conflicts, errors.Newf(nil, ErrorCodeMutuallyExclusive, "flags %s are mutually exclusive", quoteNames(conflicts, "and")))
+	})
+}
+
+// Requires makes Parse fail if the named flag is given on the command line without all of its dependencies, such as
+// "tls-key" being required whenever "tls-cert" is given.
+func (flags *Set) Requires(name string, dependencies ...string) {
+	flags.rules = append(flags.rules, func(values *Values) error {
+		if err := flags.unknownOf(append([]string{name}, dependencies...)...); err != nil {
+			return err
+		}
+
+		if !values.Given(name) {
+			return nil
+		}
+
+		var err error
+		for _, dependency := range values.missingOf(dependencies) {
+			err = errors.Append(err, newRuleError(values, []string{name, dependency}, errors.Newf(nil, ErrorCodeMissingFlag, "missing flag %q, required by %q", dependency, name)))
+		}
+		return err
+	})
+}
+
+// RequiredTogether makes Parse fail if some, but not all, of the named flags are given on the command line.
+func (flags *Set) RequiredTogether(names ...string) {
+	flags.rules = append(flags.rules, func(values *Values) error {
+		if err := flags.unknownOf(names...); err != nil {
+			return err
+		}
+
+		given := values.givenOf(names)
+		if len(given) == 0 || len(given) == len(names) {
+			return nil
+		}
+
+		missing := values.missingOf(names)
+		return newRuleError(values, names, errors.Newf(nil, ErrorCodeMissingFlag, "flags %s must be given together, missing %s", quoteNames(names, "and"), quoteNames(missing, "and")))
+	})
+}
+
+// ExactlyOneOf makes Parse fail unless exactly one of the named flags is given on the command line.
+func (flags *Set) ExactlyOneOf(names ...string) {
+	flags.rules = append(flags.rules, func(values *Values) error {
+		if err := flags.unknownOf(names...); err != nil {
+			return err
+		}
+
+		given := values.givenOf(names)
+		switch len(given) {
+		case 0:
+			return newRuleError(values, names, errors.Newf(nil, ErrorCodeMissingFlag, "exactly one of flags %s must be given", quoteNames(names, "or")))
+		case 1:
+			return nil
+		}
+		return newRuleError(values, given, errors.Newf(nil, ErrorCodeMutuallyExclusive, "flags %s are mutually exclusive", quoteNames(given, "and")))
+	})
+}
+
+// AtLeastOneOf makes Parse fail if none of the named flags are given on the command line.
+func (flags *Set) AtLeastOneOf(names ...string) {
+	flags.rules = append(flags.rules, func(values *Values) error {
+		if err := flags.unknownOf(names...); err != nil {
+			return err
+		}
+
+		if len(values.givenOf(names)) > 0 {
+			return nil
+		}
+		return newRuleError(values, names, errors.Newf(nil, ErrorCodeMissingFlag, "at least one of flags %s must be given", quoteNames(names, "or")))
+	})
+}
+
+// Condition is a predicate over the flags passed to Parse, used by RequiredWhen and RequiredUnless.
+type Condition struct {
+	description string
+	check       func(values *Values) (bool, error)
+
+	// names lists the flags the condition refers to, so rules can check they exist.
+	names []string
+}
+
+// Given is satisfied when the named flag is given on the command line.
+func Given(name string) Condition {
+	return Condition{
+		description: fmt.Sprintf("%q is given", name),
+		check: func(values *Values) (bool, error) {
+			return values.Given(name), nil
+		},
+		names: []string{name},
+	}
+}
+
+// Equals is satisfied when the named flag resolves to the given value, either from the command line or its default.
+// The type of value must match the type of the flag exactly, such as uint16(8080) for a flag bound with BindUint16,
+// otherwise the rule using the condition fails with ErrorCodeUnsupportedType.
+func Equals[T comparable](name string, value T) Condition {
+	return Condition{
+		description: fmt.Sprintf("%q is %v", name, value),
+		check: func(values *Values) (bool, error) {
+			actual, ok := values.Get(name)
+			if !ok {
+				return false, nil
+			}
+
+			typed, ok := actual.(T)
+			if !ok {
+				return false, errors.Newf(nil, ErrorCodeUnsupportedType, "condition compares flag %q of type %T with a value of type %T", name, actual, value)
+			}
+			return typed == value, nil
+		},
+		names: []string{name},
+	}
+}
+
+// RequiredWhen makes Parse fail if the named flag isn't given on the command line while the condition holds, such as
+// "bucket" being required when "backend" equals "s3".
+func (flags *Set) RequiredWhen(name string, condition Condition) {
+	flags.rules = append(flags.rules, func(values *Values) error {
+		if err := flags.unknownOf(append([]string{name}, condition.names...)...); err != nil {
+			return err
+		}
+
+		holds, err := condition.check(values)
+		if err != nil {
+			return err
+		}
+
+		if values.Given(name) || !holds {
+			return nil
+		}
+		return newRuleError(values, append([]string{name}, condition.names...), errors.Newf(nil, ErrorCodeMissingFlag, "missing flag %q, required when %s", name, condition.description))
+	})
+}
+
+// RequiredUnless makes Parse fail if the named flag isn't given on the command line and the condition doesn't hold,
+// such as "password" being required unless "password-file" is given.
+func (flags *Set) RequiredUnless(name string, condition Condition) {
+	flags.rules = append(flags.rules, func(values *Values) error {
+		if err := flags.unknownOf(append([]string{name}, condition.names...)...); err != nil {
+			return err
+		}
+
+		holds, err := condition.check(values)
+		if err != nil {
+			return err
+		}
+
+		if values.Given(name) || holds {
+			return nil
+		}
+		return newRuleError(values, append([]string{name}, condition.names...), errors.Newf(nil, ErrorCodeMissingFlag, "missing flag %q, required unless %s", name, condition.description))
+	})
+}
+
+// unknownOf returns an error for each of the names that isn't a registered flag. Aliases aren't accepted, as rules are
+// checked against the canonical names of flags.
+func (flags *Set) unknownOf(names ...string) error {
+	var err error
+	for _, name := range names {
+		if _, exists := flags.Flags[name]; !exists {
+			err = errors.Append(err, errors.Newf(nil, ErrorCodeInvalidRule, "rule refers to unknown flag %q", name))
+		}
+	}
+	return err
+}
+
+// givenOf returns the names, in order, that were given on the command line.
+func (values *Values) givenOf(names []string) []string {
+	var given []string
+	for _, name := range names {
+		if values.Given(name) {
+			given = append(given, name)
+		}
+	}
+	return given
+}
+
+// missingOf returns the names, in order, that were not given on the command line.
+func (values *Values) missingOf(names []string) []string {
+	var missing []string
+	for _, name := range names {
+		if !values.Given(name) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// quoteNames formats flag names for use in error messages, such as `"json" and "yaml"` or `"json" or "yaml"`.
+func quoteNames(names []string, conjunction string) string {
+	quoted := make([]string, len(names))
+	for ix, name := range names {
+		quoted[ix] = fmt.Sprintf("%q", name)
+	}
+
+	if len(quoted) < 2 {
+		return strings.Join(quoted, "")
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " " + conjunction + " " + quoted[len(quoted)-1]
+}
@@ -0,0 +1,197 @@
+package flags
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pasataleo/go-errors/errors"
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_MutuallyExclusive(t *testing.T) {
+	var json, yaml bool
+
+	flags := NewSet()
+
+	BindBoolean("json", "", true, false).ToValue(flags, &json)
+	BindBoolean("yaml", "", true, false).ToValue(flags, &yaml)
+	flags.MutuallyExclusive("json", "yaml")
+
+	args := []string{"--json"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(json).Equal(t, true)
+
+	args = []string{"--json", "--no-yaml"}
+	tests.Execute2E(flags.Parse(args)).Validate(t, func(err error) {
+		if !strings.Contains(err.Error(), `flags "json" and "yaml" are mutually exclusive`) {
+			t.Errorf("expected both flags in the error, got %q", err.Error())
+		}
+	})
+}
+
+func TestFlags_MutuallyExclusiveDefaults(t *testing.T) {
+	var all bool
+	var name string
+
+	flags := NewSet()
+
+	BindBoolean("all", "", true, true).ToValue(flags, &all)
+	BindString("name", "", true, "default").ToValue(flags, &name)
+	BindString("other", "", true, "").ToValue(flags, new(string))
+	flags.MutuallyExclusive("all", "name", "other")
+
+	args := []string{"--name", "web"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(all).Equal(t, true)
+	tests.Execute(name).Equal(t, "web")
+
+	args = []string{"--all", "--name", "web", "--other=x"}
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeMutuallyExclusive)
+}
+
+func TestFlags_Requires(t *testing.T) {
+	var cert, key string
+
+	flags := NewSet()
+
+	BindString("tls-cert", "", true, "").ToValue(flags, &cert)
+	BindString("tls-key", "", true, "").ToValue(flags, &key)
+	flags.Requires("tls-cert", "tls-key")
+
+	tests.Execute2E(flags.Parse(nil)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--tls-key=key.pem"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--tls-cert=cert.pem", "--tls-key=key.pem"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--tls-cert=cert.pem"})).ErrorCode(t, ErrorCodeMissingFlag)
+}
+
+func TestFlags_RequiredTogether(t *testing.T) {
+	flags := NewSet()
+
+	BindString("user", "", true, "").ToValue(flags, new(string))
+	BindString("password", "", true, "").ToValue(flags, new(string))
+	flags.RequiredTogether("user", "password")
+
+	tests.Execute2E(flags.Parse(nil)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--user=a", "--password=b"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--password=b"})).Validate(t, func(err error) {
+		if !strings.Contains(err.Error(), `flags "user" and "password" must be given together, missing "user"`) {
+			t.Errorf("expected the missing flag in the error, got %q", err.Error())
+		}
+	})
+}
+
+func TestFlags_OneOf(t *testing.T) {
+	flags := NewSet()
+
+	BindString("file", "", true, "").ToValue(flags, new(string))
+	BindString("url", "", true, "").ToValue(flags, new(string))
+	BindString("stdin", "", true, "").ToValue(flags, new(string))
+	flags.ExactlyOneOf("file", "url")
+	flags.AtLeastOneOf("url", "stdin")
+
+	tests.Execute2E(flags.Parse([]string{"--url=x"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--file=x", "--url=y"})).ErrorCode(t, ErrorCodeMutuallyExclusive)
+
+	// Both rules fail, and are reported together.
+	tests.Execute2E(flags.Parse(nil)).Validate(t, func(err error) {
+		for _, message := range []string{
+			`exactly one of flags "file" or "url" must be given`,
+			`at least one of flags "url" or "stdin" must be given`,
+		} {
+			if !strings.Contains(err.Error(), message) {
+				t.Errorf("expected %q in the error, got %q", message, err.Error())
+			}
+		}
+	})
+}
+
+func TestFlags_RequiredWhen(t *testing.T) {
+	var backend, bucket string
+
+	flags := NewSet()
+
+	BindString("backend", "", true, "local").ToValue(flags, &backend)
+	BindString("bucket", "", true, "").ToValue(flags, &bucket)
+	flags.RequiredWhen("bucket", Equals("backend", "s3"))
+
+	tests.Execute2E(flags.Parse(nil)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--backend=s3", "--bucket=backups"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--backend=s3"})).Validate(t, func(err error) {
+		if !strings.Contains(err.Error(), `missing flag "bucket", required when "backend" is s3`) {
+			t.Errorf("expected the condition in the error, got %q", err.Error())
+		}
+	})
+}
+
+func TestFlags_RequiredWhenDefault(t *testing.T) {
+	flags := NewSet()
+
+	BindString("backend", "", true, "s3").ToValue(flags, new(string))
+	BindString("bucket", "", true, "").ToValue(flags, new(string))
+	flags.RequiredWhen("bucket", Equals("backend", "s3"))
+
+	// The condition is checked against the default value of backend.
+	tests.Execute2E(flags.Parse(nil)).ErrorCode(t, ErrorCodeMissingFlag)
+}
+
+func TestFlags_RequiredWhenEqualsType(t *testing.T) {
+	flags := NewSet()
+
+	BindUint16("port", "", true, 8080).ToValue(flags, new(uint16))
+	BindString("proxy", "", true, "").ToValue(flags, new(string))
+	flags.RequiredWhen("proxy", Equals("port", uint16(8080)))
+
+	tests.Execute2E(flags.Parse(nil)).ErrorCode(t, ErrorCodeMissingFlag)
+	tests.Execute2E(flags.Parse([]string{"--port=443"})).NoError(t).Equal(t, make([]string, 0))
+
+	// An untyped constant is an int, which never matches a uint16 flag.
+	flags.RequiredWhen("proxy", Equals("port", 8080))
+	tests.Execute2E(flags.Parse([]string{"--port=443"})).Validate(t, func(err error) {
+		if !errors.Is(err, ErrorCodeUnsupportedType) || !strings.Contains(err.Error(), `condition compares flag "port" of type uint16 with a value of type int`) {
+			t.Errorf("expected the mismatched types in the error, got %q", err.Error())
+		}
+	})
+}
+
+func TestFlags_RequiredUnless(t *testing.T) {
+	flags := NewSet()
+
+	BindString("password", "", true, "").ToValue(flags, new(string))
+	BindString("password-file", "", true, "").ToValue(flags, new(string))
+	flags.RequiredUnless("password", Given("password-file"))
+
+	tests.Execute2E(flags.Parse([]string{"--password=hunter2"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--password-file=secret.txt"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse(nil)).Validate(t, func(err error) {
+		if !strings.Contains(err.Error(), `missing flag "password", required unless "password-file" is given`) {
+			t.Errorf("expected the condition in the error, got %q", err.Error())
+		}
+	})
+}
+
+func TestFlags_RulesUnknownFlag(t *testing.T) {
+	rules := map[string]func(flags *Set){
+		"MutuallyExclusive": func(flags *Set) { flags.MutuallyExclusive("json", "jsno") },
+		"Requires":          func(flags *Set) { flags.Requires("json", "jsno") },
+		"RequiredTogether":  func(flags *Set) { flags.RequiredTogether("jsno", "json") },
+		"ExactlyOneOf":      func(flags *Set) { flags.ExactlyOneOf("json", "jsno") },
+		"AtLeastOneOf":      func(flags *Set) { flags.AtLeastOneOf("json", "jsno") },
+		"RequiredWhen":      func(flags *Set) { flags.RequiredWhen("json", Given("jsno")) },
+		"RequiredUnless":    func(flags *Set) { flags.RequiredUnless("jsno", Equals("json", true)) },
+	}
+
+	for name, add := range rules {
+		t.Run(name, func(t *testing.T) {
+			flags := NewSet()
+
+			BindBoolean("json", "", true, false).ToValue(flags, new(bool))
+			add(flags)
+
+			tests.Execute2E(flags.Parse([]string{"--json"})).Validate(t, func(err error) {
+				if !errors.Is(err, ErrorCodeInvalidRule) || errors.Is(err, ErrorCodeUnknownFlag) || !strings.Contains(err.Error(), `rule refers to unknown flag "jsno"`) {
+					t.Errorf("expected the unknown flag in the error, got %q", err.Error())
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package flags
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readValueSource resolves values that reference another source. A value of "@-" is read from stdin and a value of
+// "@path" is read from the file at path. Any other value is returned as is.
+func readValueSource(ctx *parseContext, arg string) ([]byte, error) {
+	source, ok := strings.CutPrefix(arg, "@")
+	if !ok {
+		return []byte(arg), nil
+	}
+
+	if source == "-" {
+		return ctx.readStdin()
+	}
+	return os.ReadFile(source)
+}
+
+func jsonParser[T any](ctx *parseContext, strict bool) func(arg string) (T, error) {
+	return func(arg string) (T, error) {
+		var value T
+
+		data, err := readValueSource(ctx, arg)
+		if err != nil {
+			return value, err
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		if strict {
+			decoder.DisallowUnknownFields()
+		}
+
+		if err := decoder.Decode(&value); err != nil {
+			offset := decoder.InputOffset()
+			switch err := err.(type) {
+			case *json.SyntaxError:
+				offset = err.Offset
+			case *json.UnmarshalTypeError:
+				offset = err.Offset
+			}
+			if err == io.ErrUnexpectedEOF || err == io.EOF {
+				offset = int64(len(data))
+			}
+			return value, fmt.Errorf("invalid JSON at offset %d: %w", offset, err)
+		}
+
+		if _, err := decoder.Token(); err != io.EOF {
+			return value, fmt.Errorf("invalid JSON at offset %d: unexpected data after top-level value", decoder.InputOffset())
+		}
+		return value, nil
+	}
+}
+
+func jsonFormatter[T any](value T) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// BindJSON binds a flag whose value is a JSON document decoded into T. Values starting with "@" are read from the
+// named file, or from stdin for "@-". If strict is set, objects containing fields unknown to T are rejected.
+func BindJSON[T any](name string, description string, optional bool, defaultValue T, strict bool) *Binder[T] {
+	ctx := &parseContext{}
+	return &Binder[T]{
+		flag: &Flag[T]{
+			Name:         name,
+			Default:      defaultValue,
+			Optional:     optional,
+			Description:  description,
+			parser:       Scalar(jsonParser[T](ctx, strict)),
+			formatter:    jsonFormatter[T],
+			parseContext: ctx,
+		},
+	}
+}
+
+// BindJSONSlice is the repeated version of BindJSON, each occurrence of the flag is decoded separately.
+func BindJSONSlice[T any](name string, description string, optional bool, defaultValue []T, strict bool) *Binder[[]T] {
+	ctx := &parseContext{}
+	return &Binder[[]T]{
+		flag: &Flag[[]T]{
+			Name:         name,
+			Default:      defaultValue,
+			Optional:     optional,
+			Description:  description,
+			parser:       Slice(Scalar(jsonParser[T](ctx, strict))),
+			formatter:    jsonFormatter[[]T],
+			parseContext: ctx,
+		},
+	}
+}
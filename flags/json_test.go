@@ -0,0 +1,70 @@
+package flags
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+type jsonFilter struct {
+	Name  string `json:"name"`
+	Limit int    `json:"limit"`
+}
+
+func TestFlags_JSON(t *testing.T) {
+	var filter jsonFilter
+
+	flags := NewSet()
+
+	BindJSON("filter", "", false, jsonFilter{}, true).ToValue(flags, &filter)
+
+	args := []string{`--filter={"name": "hello", "limit": 5}`}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(filter).Equal(t, jsonFilter{Name: "hello", Limit: 5})
+}
+
+func TestFlags_JSONFromFile(t *testing.T) {
+	var filter jsonFilter
+
+	path := filepath.Join(t.TempDir(), "filter.json")
+	if err := os.WriteFile(path, []byte(`{"name": "file"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	flags := NewSet()
+
+	BindJSON("filter", "", false, jsonFilter{}, false).ToValue(flags, &filter)
+
+	args := []string{"--filter", "@" + path}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(filter).Equal(t, jsonFilter{Name: "file"})
+}
+
+func TestFlags_JSONStrict(t *testing.T) {
+	var filter jsonFilter
+
+	flags := NewSet()
+
+	BindJSON("filter", "", false, jsonFilter{}, true).ToValue(flags, &filter)
+
+	args := []string{`--filter={"name": "hello", "unknown": true}`}
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+func TestFlags_JSONErrorOffset(t *testing.T) {
+	var filter jsonFilter
+
+	flags := NewSet()
+
+	BindJSON("filter", "", false, jsonFilter{}, false).ToValue(flags, &filter)
+
+	args := []string{`--filter={"name": "hello", "limit": "five"}`}
+	tests.Execute2E(flags.Parse(args)).Validate(t, func(err error) {
+		if !strings.Contains(err.Error(), "offset 33") {
+			t.Errorf("expected offset in error, got %q", err.Error())
+		}
+	})
+}
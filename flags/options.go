@@ -0,0 +1,137 @@
+package flags
+
+import (
+	"io"
+	"os"
+)
+
+// ParseOption configures a single call to Set.Parse.
+type ParseOption interface {
+	apply(options *parseOptions)
+}
+
+type parseOptions struct {
+	strict   bool
+	readOnly bool
+
+	// dryRun resolves every flag without writing to any targets.
+	dryRun bool
+
+	// atomic only writes to targets if every flag and rule succeeded.
+	atomic bool
+
+	// stdin is read by values that refer to stdin, such as "@-" for JSON flags.
+	stdin io.Reader
+
+	// warnings receives warnings produced while parsing, overriding Set.Warnings.
+	warnings io.Writer
+}
+
+func newParseOptions(options []ParseOption) *parseOptions {
+	opts := &parseOptions{
+		stdin: os.Stdin,
+	}
+	for _, option := range options {
+		option.apply(opts)
+	}
+	return opts
+}
+
+type parseOptionFn func(options *parseOptions)
+
+func (fn parseOptionFn) apply(options *parseOptions) {
+	fn(options)
+}
+
+// ParseBehavior is kept for compatibility with older callers, new code should use the equivalent ParseOption such as
+// Strict or ReadOnly. The zero value has no effect.
+//
+// Set.Parse used to accept ...ParseBehavior, and now accepts ...ParseOption. Individual behaviours can still be passed
+// as before, but callers that spread a []ParseBehavior must wrap it with Behaviors instead.
+type ParseBehavior int
+
+const (
+	// ParseBehaviorStrict will make the Parse function return an error if any of the flags are unknown.
+	ParseBehaviorStrict ParseBehavior = iota + 1
+
+	// ParseBehaviorReadOnly is used to parse flags without modifying the underlying arguments.
+	ParseBehaviorReadOnly
+)
+
+func (behaviour ParseBehavior) apply(options *parseOptions) {
+	switch behaviour {
+	case ParseBehaviorStrict:
+		options.strict = true
+	case ParseBehaviorReadOnly:
+		options.readOnly = true
+	}
+}
+
+// Behaviors combines behaviours into a single ParseOption, for callers that build a []ParseBehavior:
+//
+//	flags.Parse(args, flags.Behaviors(behaviours...))
+func Behaviors(behaviours ...ParseBehavior) ParseOption {
+	return parseOptionFn(func(options *parseOptions) {
+		for _, behaviour := range behaviours {
+			behaviour.apply(options)
+		}
+	})
+}
+
+// Strict makes Parse return an error for any unknown flags.
+func Strict() ParseOption {
+	return parseOptionFn(func(options *parseOptions) {
+		options.strict = true
+	})
+}
+
+// ReadOnly makes Parse return the arguments unmodified, rather than removing the flags it consumed.
+func ReadOnly() ParseOption {
+	return parseOptionFn(func(options *parseOptions) {
+		options.readOnly = true
+	})
+}
+
+// DryRun makes Parse resolve and validate every flag without writing to any targets or calling any TargetFn. Use
+// Set.Resolve to also inspect the values that would have been written.
+func DryRun() ParseOption {
+	return parseOptionFn(func(options *parseOptions) {
+		options.dryRun = true
+	})
+}
+
+// Atomic makes Parse write to targets and call TargetFns only if every flag parsed and every rule and validator
+// passed, so a failed Parse leaves the program state untouched. If a TargetFn fails part way through, targets bound
+// with ToValue or BindStruct that were already written are restored to their previous values. Side effects of
+// TargetFns that already ran can't be undone.
+func Atomic() ParseOption {
+	return parseOptionFn(func(options *parseOptions) {
+		options.atomic = true
+	})
+}
+
+// WithStdin replaces os.Stdin as the source for values read from stdin, such as "@-" for JSON flags or "-" for
+// BindFile. The reader is read to the end at most once, and what was read is reused by every call to Parse or Resolve
+// on the same Set that passes the same reader.
+func WithStdin(stdin io.Reader) ParseOption {
+	return parseOptionFn(func(options *parseOptions) {
+		options.stdin = stdin
+	})
+}
+
+// WithWarnings replaces Set.Warnings as the destination for warnings, such as the use of deprecated flags.
+func WithWarnings(w io.Writer) ParseOption {
+	return parseOptionFn(func(options *parseOptions) {
+		options.warnings = w
+	})
+}
+
+// parseContext passes the options of the current call to Parse to the parsers of flags that depend on them. Binders
+// that need it share a parseContext between their parser and Flag.parseContext, which Parse fills in before parsing.
+type parseContext struct {
+	// stdin buffers the reader given by WithStdin, or os.Stdin, so it is only read once per Set.
+	stdin *stdinBuffer
+
+	// path is the output path resolved by the parser, for the acquire hook of the flag to create.
+	path string
+}
@@ -0,0 +1,99 @@
+package flags
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_ParseBehaviorZeroValue(t *testing.T) {
+	var behaviour ParseBehavior
+
+	flags := NewSet()
+
+	args := []string{"--unknown"}
+	tests.Execute2E(flags.Parse(args, behaviour)).NoError(t).Equal(t, args)
+	tests.Execute2E(flags.Parse(args, ParseBehaviorStrict)).ErrorCode(t, ErrorCodeUnknownFlag)
+}
+
+func TestFlags_ParseOptions(t *testing.T) {
+	flags := NewSet()
+
+	BindString("name", "", true, "").ToValue(flags, new(string))
+
+	args := []string{"--name=a", "--unknown"}
+	tests.Execute2E(flags.Parse(args, Strict())).ErrorCode(t, ErrorCodeUnknownFlag)
+	tests.Execute2E(flags.Parse(args, ReadOnly())).NoError(t).Equal(t, args)
+}
+
+func TestFlags_WithStdin(t *testing.T) {
+	var config map[string]int
+
+	flags := NewSet()
+
+	BindJSON("config", "", false, map[string]int(nil), false).ToValue(flags, &config)
+
+	args := []string{"--config=@-"}
+	tests.Execute2E(flags.Parse(args, WithStdin(strings.NewReader(`{"replicas": 3}`)))).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(config).Equal(t, map[string]int{"replicas": 3})
+}
+
+func TestFlags_WithWarnings(t *testing.T) {
+	var defaults, override bytes.Buffer
+
+	flags := NewSet()
+	flags.Warnings = &defaults
+
+	BindString("out", "", true, "").Deprecated("renamed", "").ToValue(flags, new(string))
+
+	tests.Execute2E(flags.Parse([]string{"--out=x"}, WithWarnings(&override))).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(defaults.Len()).Equal(t, 0)
+	tests.Execute(override.String()).Equal(t, "warning: flag \"out\" is deprecated: renamed\n")
+}
+
+func TestFlags_WithStdinFile(t *testing.T) {
+	var file *os.File
+
+	flags := NewSet()
+	defer flags.Close()
+
+	BindFile("input", "", false, nil, PathOptions{}).ToValue(flags, &file)
+
+	tests.Execute2E(flags.Parse([]string{"--input=-"}, WithStdin(strings.NewReader("hello")))).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(io.ReadAll(file)).NoError(t).Equal(t, []byte("hello"))
+}
+
+func TestFlags_Behaviors(t *testing.T) {
+	behaviours := []ParseBehavior{ParseBehaviorStrict, ParseBehaviorReadOnly}
+
+	flags := NewSet()
+
+	BindString("name", "", true, "").ToValue(flags, new(string))
+
+	tests.Execute2E(flags.Parse([]string{"--unknown"}, Behaviors(behaviours...))).ErrorCode(t, ErrorCodeUnknownFlag)
+	tests.Execute2E(flags.Parse([]string{"--name=a"}, Behaviors(behaviours...))).NoError(t).Equal(t, []string{"--name=a"})
+}
+
+func TestFlags_WithStdinFileClose(t *testing.T) {
+	flags := NewSet()
+
+	BindFile("input", "", false, nil, PathOptions{}).ToValue(flags, new(*os.File))
+
+	// More than fits in a pipe, so the goroutine writing it blocks until the pipe is closed.
+	goroutines := runtime.NumGoroutine()
+	stdin := WithStdin(bytes.NewReader(make([]byte, 1<<20)))
+	tests.Execute2E(flags.Parse([]string{"--input=-"}, stdin)).NoError(t).Equal(t, make([]string, 0))
+	tests.ExecuteE(flags.Close()).NoError(t)
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	tests.Execute(runtime.NumGoroutine() <= goroutines).Equal(t, true)
+}
@@ -0,0 +1,132 @@
+package flags
+
+import (
+	stderrors "errors"
+
+	"github.com/pasataleo/go-errors/errors"
+)
+
+var (
+	_ error           = (*ParseError)(nil)
+	_ errors.Codeable = (*ParseError)(nil)
+)
+
+// ParseError describes a single failure from Parse, including where the offending flag appeared in the arguments.
+type ParseError struct {
+	// Flag is the name of the flag that failed. For failures that span several flags, such as a MutuallyExclusive
+	// group, it is the involved flag that appeared first in the arguments, or the first involved flag if none did. It is
+	// empty for failures from Set.Validate.
+	Flag string
+
+	// Flags lists every flag involved in a failure that spans several flags, such as every flag of a MutuallyExclusive
+	// group that was given. It is empty for failures about a single flag.
+	Flags []string
+
+	// Alias is the name the flag was given as on the command line, which may be one of its aliases.
+	Alias string
+
+	// Values holds the raw values given for the flag. Values of sensitive flags are redacted, and values of unknown
+	// flags are never recorded.
+	Values []string
+
+	// Index is the position within the arguments of the first occurrence of the flag, or -1 if the flag wasn't given.
+	Index int
+
+	// Suggestions holds the registered flag names closest to an unknown flag, from the best match.
+	Suggestions []string
+
+	Code errors.ErrorCode
+	Err  error
+}
+
+func newParseError(name string, occurrence *occurrence, err error) *ParseError {
+	parseErr := &ParseError{
+		Flag:  name,
+		Index: -1,
+		Code:  errors.GetErrorCode(err),
+		Err:   err,
+	}
+	if occurrence != nil {
+		parseErr.Alias = occurrence.alias
+		parseErr.Values = occurrence.values
+		parseErr.Index = occurrence.index
+	}
+	return parseErr
+}
+
+// newRuleError returns a ParseError for a rule that failed because of the named flags. It points at whichever of them
+// appeared first in the arguments, so the offending argument can be highlighted.
+func newRuleError(values *Values, names []string, err error) *ParseError {
+	if len(names) == 0 {
+		return newParseError("", nil, err)
+	}
+
+	first := names[0]
+	for _, name := range names {
+		occurrence, given := values.occurrences[name]
+		if !given {
+			continue
+		}
+		if current, ok := values.occurrences[first]; !ok || occurrence.index < current.index {
+			first = name
+		}
+	}
+
+	parseErr := newParseError(first, values.occurrences[first], err)
+	parseErr.Flags = append([]string(nil), names...)
+	return parseErr
+}
+
+// Error implements the error interface.
+func (err *ParseError) Error() string {
+	return err.Err.Error()
+}
+
+// GetErrorCode implements the errors.Codeable interface.
+func (err *ParseError) GetErrorCode() errors.ErrorCode {
+	return err.Code
+}
+
+func (err *ParseError) Unwrap() error {
+	return err.Err
+}
+
+// ParseErrors lists every failure aggregated into an error returned by Parse. Failures that aren't about particular
+// flags, such as those from Set.Validate, are returned with an empty Flag and an Index of -1.
+func ParseErrors(err error) []*ParseError {
+	var parseErrs []*ParseError
+	for _, expanded := range errors.Expand(err) {
+		if errors.Is(expanded, errors.ErrorCodeMulti) {
+			parseErrs = append(parseErrs, ParseErrors(expanded)...)
+			continue
+		}
+
+		var parseErr *ParseError
+		if stderrors.As(expanded, &parseErr) {
+			parseErrs = append(parseErrs, parseErr)
+			continue
+		}
+		parseErrs = append(parseErrs, newParseError("", nil, expanded))
+	}
+	return parseErrs
+}
+
+// occurrence records where a flag appeared in the arguments passed to Parse.
+type occurrence struct {
+	alias  string
+	index  int
+	values []string
+}
+
+// redacted returns a copy of the occurrence with its values hidden, for use with sensitive flags.
+func (o *occurrence) redacted() *occurrence {
+	values := make([]string, len(o.values))
+	for ix := range values {
+		values[ix] = redacted
+	}
+	return &occurrence{
+		alias:  o.alias,
+		index:  o.index,
+		values: values,
+	}
+}
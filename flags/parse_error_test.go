@@ -0,0 +1,138 @@
+package flags
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_ParseError(t *testing.T) {
+	flags := NewSet()
+
+	BindInt("count", "", false, 0).ToValue(flags, new(int))
+	BindBoolean("verbose", "", true, false).ToValue(flags, new(bool))
+
+	args := []string{"run", "--no-verbose=maybe", "--count", "many"}
+	tests.Execute2E(flags.Parse(args)).Validate(t, func(err error) {
+		parseErrs := ParseErrors(err)
+		tests.Execute(len(parseErrs)).Equal(t, 2)
+
+		byFlag := make(map[string]*ParseError)
+		for _, parseErr := range parseErrs {
+			byFlag[parseErr.Flag] = parseErr
+		}
+
+		tests.Execute(byFlag["count"].Alias).Equal(t, "count")
+		tests.Execute(byFlag["count"].Values).Equal(t, []string{"many"})
+		tests.Execute(byFlag["count"].Index).Equal(t, 2)
+		tests.Execute(byFlag["count"].Code).Equal(t, ErrorCodeInvalidValue)
+
+		tests.Execute(byFlag["verbose"].Alias).Equal(t, "no-verbose")
+		tests.Execute(byFlag["verbose"].Values).Equal(t, []string{"maybe"})
+		tests.Execute(byFlag["verbose"].Index).Equal(t, 1)
+	})
+}
+
+func TestFlags_ParseErrorAs(t *testing.T) {
+	flags := NewSet()
+
+	BindInt("count", "", false, 0).ToValue(flags, new(int))
+
+	tests.Execute2E(flags.Parse([]string{"--size=1"}, Strict())).Validate(t, func(err error) {
+		var parseErr *ParseError
+		for _, expanded := range ParseErrors(err) {
+			if expanded.Code == ErrorCodeUnknownFlag && stderrors.As(expanded, &parseErr) {
+				break
+			}
+		}
+		if parseErr == nil {
+			t.Fatalf("expected an unknown flag error, got %q", err.Error())
+		}
+		tests.Execute(parseErr.Flag).Equal(t, "size")
+		tests.Execute(parseErr.Index).Equal(t, 0)
+		tests.Execute(len(parseErr.Values)).Equal(t, 0)
+	})
+
+	tests.Execute2E(flags.Parse(nil)).Validate(t, func(err error) {
+		var parseErr *ParseError
+		if !stderrors.As(err, &parseErr) {
+			t.Fatalf("expected a ParseError, got %T", err)
+		}
+		tests.Execute(parseErr.Code).Equal(t, ErrorCodeMissingFlag)
+		tests.Execute(parseErr.Index).Equal(t, -1)
+	})
+}
+
+func TestFlags_ParseErrorSensitive(t *testing.T) {
+	flags := NewSet()
+
+	BindInt("pin", "", false, 0).Sensitive().ToValue(flags, new(int))
+	flags.Validate(func(*Values) error {
+		return stderrors.New("always fails")
+	})
+
+	tests.Execute2E(flags.Parse([]string{"--pin=12a4"})).Validate(t, func(err error) {
+		parseErrs := ParseErrors(err)
+		tests.Execute(len(parseErrs)).Equal(t, 2)
+		tests.Execute(parseErrs[0].Values).Equal(t, []string{redacted})
+		tests.Execute(parseErrs[1].Flag).Equal(t, "")
+		tests.Execute(parseErrs[1].Code).Equal(t, ErrorCodeInvalidValue)
+	})
+}
+
+func TestFlags_ParseErrorUnknownValue(t *testing.T) {
+	flags := NewSet()
+
+	BindString("token", "", true, "").Sensitive().ToValue(flags, new(string))
+
+	tests.Execute2E(flags.Parse([]string{"--tokne=hunter2"}, Strict())).Validate(t, func(err error) {
+		parseErrs := ParseErrors(err)
+		tests.Execute(len(parseErrs)).Equal(t, 1)
+		tests.Execute(parseErrs[0].Suggestions).Equal(t, []string{"token"})
+		tests.Execute(len(parseErrs[0].Values)).Equal(t, 0)
+		if strings.Contains(err.Error(), "hunter2") {
+			t.Errorf("expected the value to be left out of the error, got %q", err.Error())
+		}
+	})
+}
+
+func TestFlags_ParseErrorRules(t *testing.T) {
+	flags := NewSet()
+
+	BindBoolean("json", "", true, false).ToValue(flags, new(bool))
+	BindBoolean("yaml", "", true, false).ToValue(flags, new(bool))
+	BindString("backend", "", true, "local").ToValue(flags, new(string))
+	BindString("bucket", "", true, "").ToValue(flags, new(string))
+	BindString("pin", "", true, "").Sensitive().ToValue(flags, new(string))
+	BindString("user", "", true, "").ToValue(flags, new(string))
+	flags.MutuallyExclusive("json", "yaml")
+	flags.RequiredWhen("bucket", Equals("backend", "s3"))
+	flags.Requires("pin", "user")
+
+	args := []string{"--yaml", "--backend", "s3", "--json", "--pin=1234"}
+	tests.Execute2E(flags.Parse(args)).Validate(t, func(err error) {
+		byFlag := make(map[string]*ParseError)
+		for _, parseErr := range ParseErrors(err) {
+			byFlag[parseErr.Flag] = parseErr
+		}
+
+		// Each failure points at the first of its flags on the command line, and lists every flag involved.
+		exclusive := byFlag["yaml"]
+		tests.Execute(exclusive.Code).Equal(t, ErrorCodeMutuallyExclusive)
+		tests.Execute(exclusive.Index).Equal(t, 0)
+		tests.Execute(exclusive.Flags).Equal(t, []string{"json", "yaml"})
+
+		required := byFlag["backend"]
+		tests.Execute(required.Code).Equal(t, ErrorCodeMissingFlag)
+		tests.Execute(required.Index).Equal(t, 1)
+		tests.Execute(required.Values).Equal(t, []string{"s3"})
+		tests.Execute(required.Flags).Equal(t, []string{"bucket", "backend"})
+
+		requires := byFlag["pin"]
+		tests.Execute(requires.Index).Equal(t, 4)
+		tests.Execute(requires.Values).Equal(t, []string{redacted})
+		tests.Execute(requires.Flags).Equal(t, []string{"pin", "user"})
+	})
+}
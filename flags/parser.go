@@ -1,6 +1,7 @@
 package flags
 
 import (
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -61,251 +62,132 @@ func (p *singleArgParser[T]) Parse(name string, args []string) (T, error) {
 	return value, nil
 }
 
-type sliceArgParser[T any] struct {
-	parser func(arg string) (T, error)
-}
-
-func (p *sliceArgParser[T]) Parse(name string, args []string) ([]T, error) {
-	if len(args) == 0 {
-		return nil, errors.Newf(nil, ErrorCodeMissingFlag, "missing flag %q", name)
-	}
-
-	var result []T
-	for _, arg := range args {
-		value, err := p.parser(arg)
-		if err != nil {
-			return nil, errors.Newf(err, ErrorCodeInvalidValue, "invalid value for flag %q", name)
+// normalizeInteger prepares an integer literal for parsing. Literals with a 0b, 0o or 0x prefix are returned unchanged
+// with base 0 so the prefix selects the base. Anything else is treated as decimal, even with leading zeros, and has
+// the underscores between its digits removed.
+func normalizeInteger(arg string) (string, int, bool) {
+	digits := strings.TrimLeft(arg, "+-")
+	if len(digits) > 1 && digits[0] == '0' {
+		switch digits[1] {
+		case 'b', 'B', 'o', 'O', 'x', 'X':
+			return arg, 0, true
 		}
-		result = append(result, value)
-	}
-	return result, nil
-}
-
-func intParser() Parser[int] {
-	return &singleArgParser[int]{
-		parser: strconv.Atoi,
-	}
-}
-
-func intSliceParser() Parser[[]int] {
-	return &sliceArgParser[int]{
-		parser: strconv.Atoi,
-	}
-}
-
-func int8Parser() Parser[int8] {
-	return &singleArgParser[int8]{
-		parser: func(arg string) (int8, error) {
-			value, err := strconv.ParseInt(arg, 10, 8)
-			return int8(value), err
-		},
-	}
-}
-
-func int8SliceParser() Parser[[]int8] {
-	return &sliceArgParser[int8]{
-		parser: func(arg string) (int8, error) {
-			value, err := strconv.ParseInt(arg, 10, 8)
-			return int8(value), err
-		},
 	}
-}
 
-func int16Parser() Parser[int16] {
-	return &singleArgParser[int16]{
-		parser: func(arg string) (int16, error) {
-			value, err := strconv.ParseInt(arg, 10, 16)
-			return int16(value), err
-		},
+	if !strings.Contains(arg, "_") {
+		return arg, 10, true
 	}
-}
 
-func int16SliceParser() Parser[[]int16] {
-	return &sliceArgParser[int16]{
-		parser: func(arg string) (int16, error) {
-			value, err := strconv.ParseInt(arg, 10, 16)
-			return int16(value), err
-		},
+	isDigit := func(c byte) bool {
+		return c >= '0' && c <= '9'
 	}
-}
 
-func int32Parser() Parser[int32] {
-	return &singleArgParser[int32]{
-		parser: func(arg string) (int32, error) {
-			value, err := strconv.ParseInt(arg, 10, 32)
-			return int32(value), err
-		},
-	}
-}
-
-func int32SliceParser() Parser[[]int32] {
-	return &sliceArgParser[int32]{
-		parser: func(arg string) (int32, error) {
-			value, err := strconv.ParseInt(arg, 10, 32)
-			return int32(value), err
-		},
-	}
-}
-
-func int64Parser() Parser[int64] {
-	return &singleArgParser[int64]{
-		parser: func(arg string) (int64, error) {
-			value, err := strconv.ParseInt(arg, 10, 64)
-			return int64(value), err
-		},
-	}
-}
-
-func int64SliceParser() Parser[[]int64] {
-	return &sliceArgParser[int64]{
-		parser: func(arg string) (int64, error) {
-			value, err := strconv.ParseInt(arg, 10, 64)
-			return int64(value), err
-		},
-	}
-}
-
-func uintParser() Parser[uint] {
-	return &singleArgParser[uint]{
-		parser: func(arg string) (uint, error) {
-			value, err := strconv.ParseUint(arg, 10, 0)
-			return uint(value), err
-		},
-	}
-}
-
-func uintSliceParser() Parser[[]uint] {
-	return &sliceArgParser[uint]{
-		parser: func(arg string) (uint, error) {
-			value, err := strconv.ParseUint(arg, 10, 0)
-			return uint(value), err
-		},
-	}
-}
-
-func uint8Parser() Parser[uint8] {
-	return &singleArgParser[uint8]{
-		parser: func(arg string) (uint8, error) {
-			value, err := strconv.ParseUint(arg, 10, 8)
-			return uint8(value), err
-		},
+	for ix := 0; ix < len(arg); ix++ {
+		if arg[ix] != '_' {
+			continue
+		}
+		if ix == 0 || ix == len(arg)-1 || !isDigit(arg[ix-1]) || !isDigit(arg[ix+1]) {
+			return arg, 10, false
+		}
 	}
+	return strings.ReplaceAll(arg, "_", ""), 10, true
 }
 
-func uint8SliceParser() Parser[[]uint8] {
-	return &sliceArgParser[uint8]{
-		parser: func(arg string) (uint8, error) {
-			value, err := strconv.ParseUint(arg, 10, 8)
-			return uint8(value), err
-		},
+func parseSigned(arg string, bitSize int) (int64, error) {
+	normalized, base, ok := normalizeInteger(arg)
+	if !ok {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: arg, Err: strconv.ErrSyntax}
 	}
-}
 
-func uint16Parser() Parser[uint16] {
-	return &singleArgParser[uint16]{
-		parser: func(arg string) (uint16, error) {
-			value, err := strconv.ParseUint(arg, 10, 16)
-			return uint16(value), err
-		},
+	value, err := strconv.ParseInt(normalized, base, bitSize)
+	if numErr, ok := err.(*strconv.NumError); ok {
+		numErr.Num = arg
 	}
+	return value, err
 }
 
-func uint16SliceParser() Parser[[]uint16] {
-	return &sliceArgParser[uint16]{
-		parser: func(arg string) (uint16, error) {
-			value, err := strconv.ParseUint(arg, 10, 16)
-			return uint16(value), err
-		},
+func parseUnsigned(arg string, bitSize int) (uint64, error) {
+	normalized, base, ok := normalizeInteger(arg)
+	if !ok {
+		return 0, &strconv.NumError{Func: "ParseUint", Num: arg, Err: strconv.ErrSyntax}
 	}
-}
 
-func uint32Parser() Parser[uint32] {
-	return &singleArgParser[uint32]{
-		parser: func(arg string) (uint32, error) {
-			value, err := strconv.ParseUint(arg, 10, 32)
-			return uint32(value), err
-		},
+	value, err := strconv.ParseUint(normalized, base, bitSize)
+	if numErr, ok := err.(*strconv.NumError); ok {
+		numErr.Num = arg
 	}
+	return value, err
 }
 
-func uint32SliceParser() Parser[[]uint32] {
-	return &sliceArgParser[uint32]{
-		parser: func(arg string) (uint32, error) {
-			value, err := strconv.ParseUint(arg, 10, 32)
-			return uint32(value), err
-		},
+func signedValue[T int | int8 | int16 | int32 | int64]() func(arg string) (T, error) {
+	bitSize := reflect.TypeFor[T]().Bits()
+	return func(arg string) (T, error) {
+		value, err := parseSigned(arg, bitSize)
+		return T(value), err
 	}
 }
 
-func uint64Parser() Parser[uint64] {
-	return &singleArgParser[uint64]{
-		parser: func(arg string) (uint64, error) {
-			value, err := strconv.ParseUint(arg, 10, 64)
-			return uint64(value), err
-		},
+func unsignedValue[T uint | uint8 | uint16 | uint32 | uint64]() func(arg string) (T, error) {
+	bitSize := reflect.TypeFor[T]().Bits()
+	return func(arg string) (T, error) {
+		value, err := parseUnsigned(arg, bitSize)
+		return T(value), err
 	}
 }
 
-func uint64SliceParser() Parser[[]uint64] {
-	return &sliceArgParser[uint64]{
-		parser: func(arg string) (uint64, error) {
-			value, err := strconv.ParseUint(arg, 10, 64)
-			return uint64(value), err
-		},
+func floatValue[T float32 | float64]() func(arg string) (T, error) {
+	bitSize := reflect.TypeFor[T]().Bits()
+	return func(arg string) (T, error) {
+		value, err := strconv.ParseFloat(arg, bitSize)
+		return T(value), err
 	}
 }
 
-func float32Parser() Parser[float32] {
-	return &singleArgParser[float32]{
-		parser: func(arg string) (float32, error) {
-			value, err := strconv.ParseFloat(arg, 32)
-			return float32(value), err
-		},
-	}
+func stringValue(arg string) (string, error) {
+	return arg, nil
 }
 
-func float32SliceParser() Parser[[]float32] {
-	return &sliceArgParser[float32]{
-		parser: func(arg string) (float32, error) {
-			value, err := strconv.ParseFloat(arg, 32)
-			return float32(value), err
-		},
-	}
+// Integer returns the parser used by the built-in signed integer flags.
+func Integer[T int | int8 | int16 | int32 | int64]() Parser[T] {
+	return Scalar(signedValue[T]())
 }
 
-func float64Parser() Parser[float64] {
-	return &singleArgParser[float64]{
-		parser: func(arg string) (float64, error) {
-			value, err := strconv.ParseFloat(arg, 64)
-			return value, err
-		},
-	}
+// Unsigned returns the parser used by the built-in unsigned integer flags.
+func Unsigned[T uint | uint8 | uint16 | uint32 | uint64]() Parser[T] {
+	return Scalar(unsignedValue[T]())
 }
 
-func float64SliceParser() Parser[[]float64] {
-	return &sliceArgParser[float64]{
-		parser: func(arg string) (float64, error) {
-			value, err := strconv.ParseFloat(arg, 64)
-			return value, err
-		},
-	}
-}
-
-func stringParser() Parser[string] {
-	return &singleArgParser[string]{
-		parser: func(arg string) (string, error) {
-			return arg, nil
-		},
-	}
+// Float returns the parser used by the built-in floating point flags.
+func Float[T float32 | float64]() Parser[T] {
+	return Scalar(floatValue[T]())
 }
 
-func stringSliceParser() Parser[[]string] {
-	return &sliceArgParser[string]{
-		parser: func(arg string) (string, error) {
-			return arg, nil
-		},
-	}
-}
+func intParser() Parser[int]                { return Integer[int]() }
+func intSliceParser() Parser[[]int]         { return Slice(intParser()) }
+func int8Parser() Parser[int8]              { return Integer[int8]() }
+func int8SliceParser() Parser[[]int8]       { return Slice(int8Parser()) }
+func int16Parser() Parser[int16]            { return Integer[int16]() }
+func int16SliceParser() Parser[[]int16]     { return Slice(int16Parser()) }
+func int32Parser() Parser[int32]            { return Integer[int32]() }
+func int32SliceParser() Parser[[]int32]     { return Slice(int32Parser()) }
+func int64Parser() Parser[int64]            { return Integer[int64]() }
+func int64SliceParser() Parser[[]int64]     { return Slice(int64Parser()) }
+func uintParser() Parser[uint]              { return Unsigned[uint]() }
+func uintSliceParser() Parser[[]uint]       { return Slice(uintParser()) }
+func uint8Parser() Parser[uint8]            { return Unsigned[uint8]() }
+func uint8SliceParser() Parser[[]uint8]     { return Slice(uint8Parser()) }
+func uint16Parser() Parser[uint16]          { return Unsigned[uint16]() }
+func uint16SliceParser() Parser[[]uint16]   { return Slice(uint16Parser()) }
+func uint32Parser() Parser[uint32]          { return Unsigned[uint32]() }
+func uint32SliceParser() Parser[[]uint32]   { return Slice(uint32Parser()) }
+func uint64Parser() Parser[uint64]          { return Unsigned[uint64]() }
+func uint64SliceParser() Parser[[]uint64]   { return Slice(uint64Parser()) }
+func float32Parser() Parser[float32]        { return Float[float32]() }
+func float32SliceParser() Parser[[]float32] { return Slice(float32Parser()) }
+func float64Parser() Parser[float64]        { return Float[float64]() }
+func float64SliceParser() Parser[[]float64] { return Slice(float64Parser()) }
+func stringParser() Parser[string]          { return Scalar(stringValue) }
+func stringSliceParser() Parser[[]string]   { return Slice(stringParser()) }
 
 type boolParser struct{}
 
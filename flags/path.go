@@ -0,0 +1,323 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pasataleo/go-errors/errors"
+)
+
+// PathKind restricts the type of filesystem entry a path flag may refer to.
+type PathKind int
+
+const (
+	// PathKindAny accepts any type of filesystem entry.
+	PathKindAny PathKind = iota
+
+	// PathKindFile only accepts regular files.
+	PathKindFile
+
+	// PathKindDir only accepts directories.
+	PathKindDir
+)
+
+// PathOptions controls how path flags are resolved and validated.
+type PathOptions struct {
+	// MustExist rejects paths that don't exist.
+	MustExist bool
+
+	// Kind restricts the type of entry the path refers to, if it exists.
+	Kind PathKind
+
+	// Readable rejects existing paths that cannot be opened.
+	Readable bool
+
+	// Writable rejects existing paths that the current user cannot write to. Paths that don't exist must have a
+	// writable parent directory. The OS filesystem is checked with access(2) where it is available, while custom
+	// filesystems, and platforms without access(2), only check that any write permission bit is set.
+	Writable bool
+
+	// Absolute resolves the path relative to the working directory.
+	Absolute bool
+
+	// FS is used for all checks, it defaults to the OS filesystem. Paths are converted to slash separated, unrooted
+	// names before being passed to a custom filesystem. Flags that open files, such as BindFile, always use the OS
+	// filesystem and so reject a custom FS.
+	FS fs.FS
+}
+
+// osFS implements fs.StatFS directly on top of the os package, accepting native paths.
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+func (osFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path, err
+	}
+	return filepath.Join(home, path[1:]), nil
+}
+
+func (opts PathOptions) resolve(arg string) (string, error) {
+	path, err := expandHome(arg)
+	if err != nil {
+		return arg, err
+	}
+
+	if opts.Absolute {
+		if path, err = filepath.Abs(path); err != nil {
+			return arg, err
+		}
+	}
+
+	if err := opts.check(path); err != nil {
+		return arg, err
+	}
+	return path, nil
+}
+
+func (opts PathOptions) check(path string) error {
+	var fsys fs.FS = osFS{}
+	name, dir := path, filepath.Dir
+	if opts.FS != nil {
+		dir = pathpkg.Dir
+		fsys = opts.FS
+		name = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "/")
+		if len(name) == 0 {
+			name = "."
+		}
+	}
+
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+
+		if opts.MustExist {
+			return fmt.Errorf("path %q does not exist", path)
+		}
+
+		if opts.Writable {
+			parent, err := fs.Stat(fsys, dir(name))
+			if err != nil || !parent.IsDir() {
+				return fmt.Errorf("parent directory of %q does not exist", path)
+			}
+			if !opts.writable(dir(name), parent) {
+				return fmt.Errorf("parent directory of %q is not writable", path)
+			}
+		}
+		return nil
+	}
+
+	switch opts.Kind {
+	case PathKindFile:
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("path %q is not a regular file", path)
+		}
+	case PathKindDir:
+		if !info.IsDir() {
+			return fmt.Errorf("path %q is not a directory", path)
+		}
+	}
+
+	if opts.Readable {
+		file, err := fsys.Open(name)
+		if err != nil {
+			return fmt.Errorf("path %q is not readable", path)
+		}
+		_ = file.Close()
+	}
+
+	if opts.Writable && !opts.writable(name, info) {
+		return fmt.Errorf("path %q is not writable", path)
+	}
+	return nil
+}
+
+// writable reports whether name, described by info, can be written to.
+func (opts PathOptions) writable(name string, info fs.FileInfo) bool {
+	if opts.FS != nil {
+		return info.Mode().Perm()&0222 != 0
+	}
+	return canWrite(name, info)
+}
+
+// openable returns an error for flags that open files if opts has a custom FS, as files are always opened through the
+// os package. It is reported when the flag is bound, as it is a mistake in the program rather than in its arguments.
+func (opts PathOptions) openable(name string) error {
+	if opts.FS != nil {
+		return errors.Newf(nil, ErrorCodeUnsupportedType, "flag %q can't open files from a custom FS", name)
+	}
+	return nil
+}
+
+// BindPath binds a filesystem path. A leading "~" is expanded to the home directory of the current user and the
+// result is validated against opts.
+func BindPath(name string, description string, optional bool, defaultValue string, opts PathOptions) *Binder[string] {
+	return &Binder[string]{
+		flag: &Flag[string]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(opts.resolve),
+		},
+	}
+}
+
+// BindPathSlice is the repeated version of BindPath.
+func BindPathSlice(name string, description string, optional bool, defaultValue []string, opts PathOptions) *Binder[[]string] {
+	return &Binder[[]string]{
+		flag: &Flag[[]string]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Slice(Scalar(opts.resolve)),
+		},
+	}
+}
+
+func closeFile(file *os.File) error {
+	if file == nil || file == os.Stdin || file == os.Stdout {
+		return nil
+	}
+	return file.Close()
+}
+
+func fileFormatter(file *os.File) string {
+	if file == nil {
+		return ""
+	}
+	if file == os.Stdin || file == os.Stdout {
+		return "-"
+	}
+	return file.Name()
+}
+
+// stdinFile returns stdin as a file. Readers other than os.Stdin, such as those given to WithStdin, are read into the
+// stdin buffer of the Set, so a reader that never ends blocks Parse rather than anything running in the background.
+// The buffered data is then written into a pipe by a goroutine, which finishes once the data has been read or the pipe
+// is closed by Set.Close.
+func stdinFile(stdin *stdinBuffer) (*os.File, error) {
+	if stdin == nil || (stdin.source == io.Reader(os.Stdin) && !stdin.read) {
+		return os.Stdin, nil
+	}
+
+	data, err := stdin.bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		_, _ = writer.Write(data)
+		_ = writer.Close()
+	}()
+	return reader, nil
+}
+
+// BindFile binds a file that is opened for reading once the flag is parsed. A value of "-" refers to stdin, or to the
+// reader given to WithStdin. Files opened by the parser are closed by Set.Close. Files are always opened from the OS
+// filesystem, so binding the flag fails if opts.FS is set.
+func BindFile(name string, description string, optional bool, defaultValue *os.File, opts PathOptions) *Binder[*os.File] {
+	ctx := &parseContext{}
+	return &Binder[*os.File]{
+		flag: &Flag[*os.File]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser: Scalar(func(arg string) (*os.File, error) {
+				if arg == "-" {
+					return stdinFile(ctx.stdin)
+				}
+
+				path, err := opts.resolve(arg)
+				if err != nil {
+					return nil, err
+				}
+				return os.Open(path)
+			}),
+			formatter:    fileFormatter,
+			closer:       closeFile,
+			parseContext: ctx,
+		},
+		err: opts.openable(name),
+	}
+}
+
+// checkOutput rejects output paths that can't be created, such as directories, while parsing. Atomic parses create
+// every output file before writing any target, so a path that only failed once created would leave the files before it
+// truncated.
+func checkOutput(path string) error {
+	if err := (PathOptions{Writable: true}).check(path); err != nil {
+		return err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return fmt.Errorf("path %q is a directory", path)
+	}
+	return nil
+}
+
+// BindOutputFile binds a file that is created, or truncated, for writing once the flag is parsed. A value of "-"
+// refers to stdout. Files opened by the parser are closed by Set.Close. The file is only created when values are written
+// to targets, so dry runs and failed atomic parses leave it untouched, and resolve the flag to nil. Paths are checked
+// for write access while parsing, so an atomic parse only fails after creating files if the filesystem changes in
+// between. As with BindFile, binding the flag fails if opts.FS is set.
+func BindOutputFile(name string, description string, optional bool, defaultValue *os.File, opts PathOptions) *Binder[*os.File] {
+	ctx := &parseContext{}
+	return &Binder[*os.File]{
+		flag: &Flag[*os.File]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser: Scalar(func(arg string) (*os.File, error) {
+				if arg == "-" {
+					return os.Stdout, nil
+				}
+
+				path, err := opts.resolve(arg)
+				if err != nil {
+					return nil, err
+				}
+				if err := checkOutput(path); err != nil {
+					return nil, err
+				}
+				ctx.path = path
+				return nil, nil
+			}),
+			acquire: func(file *os.File) (*os.File, error) {
+				if file != nil || len(ctx.path) == 0 {
+					return file, nil
+				}
+				return os.Create(ctx.path)
+			},
+			formatter:    fileFormatter,
+			closer:       closeFile,
+			parseContext: ctx,
+		},
+		err: opts.openable(name),
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package flags
+
+import "io/fs"
+
+// canWrite falls back to the permission bits on platforms without access(2).
+func canWrite(_ string, info fs.FileInfo) bool {
+	return info.Mode().Perm()&0222 != 0
+}
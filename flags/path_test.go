@@ -0,0 +1,128 @@
+package flags
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+var testFS = fstest.MapFS{
+	"etc/config.yaml": &fstest.MapFile{Data: []byte("config"), Mode: 0644},
+	"etc/readonly":    &fstest.MapFile{Data: []byte("readonly"), Mode: 0444},
+	"var/data":        &fstest.MapFile{Mode: os.ModeDir | 0755},
+}
+
+func TestFlags_Path(t *testing.T) {
+	var path string
+
+	flags := NewSet()
+
+	BindPath("config", "", false, "", PathOptions{MustExist: true, Kind: PathKindFile, FS: testFS}).ToValue(flags, &path)
+
+	args := []string{"--config=/etc/config.yaml"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(path).Equal(t, "/etc/config.yaml")
+}
+
+func TestFlags_PathChecks(t *testing.T) {
+	cases := map[string]struct {
+		opts PathOptions
+		arg  string
+	}{
+		"missing":      {PathOptions{MustExist: true}, "/etc/missing"},
+		"not a file":   {PathOptions{Kind: PathKindFile}, "/var/data"},
+		"not a dir":    {PathOptions{Kind: PathKindDir}, "/etc/config.yaml"},
+		"not writable": {PathOptions{Writable: true}, "/etc/readonly"},
+		"no parent":    {PathOptions{Writable: true}, "/missing/output"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var path string
+
+			tc.opts.FS = testFS
+			flags := NewSet()
+
+			BindPath("path", "", false, "", tc.opts).ToValue(flags, &path)
+
+			args := []string{"--path", tc.arg}
+			tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+		})
+	}
+}
+
+func TestFlags_PathExpandHome(t *testing.T) {
+	var path string
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	flags := NewSet()
+
+	BindPath("path", "", false, "", PathOptions{}).ToValue(flags, &path)
+
+	args := []string{"--path=~/config"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(path).Equal(t, filepath.Join(home, "config"))
+}
+
+func TestFlags_File(t *testing.T) {
+	var file *os.File
+
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	flags := NewSet()
+
+	BindFile("input", "", false, nil, PathOptions{MustExist: true}).ToValue(flags, &file)
+
+	args := []string{"--input", path}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(io.ReadAll(file)).NoError(t).Equal(t, []byte("hello"))
+	tests.ExecuteE(flags.Close()).NoError(t)
+	tests.Execute2E(file.Read(make([]byte, 1))).Error(t)
+}
+
+func TestFlags_FileStdin(t *testing.T) {
+	var file *os.File
+
+	flags := NewSet()
+
+	BindFile("input", "", false, nil, PathOptions{}).ToValue(flags, &file)
+
+	args := []string{"--input", "-"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(file == os.Stdin).Equal(t, true)
+	tests.ExecuteE(flags.Close()).NoError(t)
+}
+
+func TestFlags_PathWritable(t *testing.T) {
+	var path string
+
+	dir := t.TempDir()
+
+	flags := NewSet()
+
+	BindPath("path", "", false, "", PathOptions{Writable: true}).ToValue(flags, &path)
+
+	args := []string{"--path", filepath.Join(dir, "output.txt")}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+
+	args = []string{"--path", filepath.Join(dir, "missing", "output.txt")}
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+func TestFlags_FileCustomFS(t *testing.T) {
+	flags := NewSet()
+
+	// Files are always opened from the OS filesystem, so a custom FS is rejected when the flag is bound.
+	tests.ExecuteE(BindFile("input", "", false, nil, PathOptions{FS: testFS}).ToValueSafe(flags, new(*os.File))).ErrorCode(t, ErrorCodeUnsupportedType)
+	tests.ExecuteE(BindOutputFile("output", "", true, nil, PathOptions{FS: testFS}).ToValueSafe(flags, new(*os.File))).ErrorCode(t, ErrorCodeUnsupportedType)
+	tests.Execute(len(flags.Flags)).Equal(t, 0)
+}
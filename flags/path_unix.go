@@ -0,0 +1,16 @@
+//go:build unix
+
+package flags
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// accessWrite is W_OK, which has the same value on every unix platform.
+const accessWrite = 0x2
+
+// canWrite uses access(2), so ownership, group membership and read-only mounts are all taken into account.
+func canWrite(path string, _ fs.FileInfo) bool {
+	return syscall.Access(path, accessWrite) == nil
+}
@@ -0,0 +1,141 @@
+package flags
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+func regexpParser(anchored bool) func(arg string) (*regexp.Regexp, error) {
+	return func(arg string) (*regexp.Regexp, error) {
+		if anchored {
+			arg = fmt.Sprintf("^(?:%s)$", arg)
+		}
+		return regexp.Compile(arg)
+	}
+}
+
+// BindRegexp binds a regular expression flag, compiled with the regexp package. If anchored is set, the expression
+// must match the whole input rather than any substring of it.
+func BindRegexp(name string, description string, optional bool, defaultValue *regexp.Regexp, anchored bool) *Binder[*regexp.Regexp] {
+	return &Binder[*regexp.Regexp]{
+		flag: &Flag[*regexp.Regexp]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(regexpParser(anchored)),
+		},
+	}
+}
+
+// BindRegexpSlice is the repeated version of BindRegexp.
+func BindRegexpSlice(name string, description string, optional bool, defaultValue []*regexp.Regexp, anchored bool) *Binder[[]*regexp.Regexp] {
+	return &Binder[[]*regexp.Regexp]{
+		flag: &Flag[[]*regexp.Regexp]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Slice(Scalar(regexpParser(anchored))),
+		},
+	}
+}
+
+// Glob is a slash separated path pattern using the syntax of path.Match, extended so that a "**" segment matches any
+// number of path segments, including none.
+type Glob struct {
+	pattern  string
+	segments []string
+}
+
+// CompileGlob validates pattern and returns a Glob that matches it.
+func CompileGlob(pattern string) (Glob, error) {
+	segments := strings.Split(pattern, "/")
+	for _, segment := range segments {
+		if segment == "**" {
+			continue
+		}
+
+		if strings.Contains(segment, "**") {
+			return Glob{}, fmt.Errorf("invalid glob %q: ** must be a whole path segment", pattern)
+		}
+
+		if _, err := path.Match(segment, ""); err != nil {
+			return Glob{}, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+	}
+
+	return Glob{
+		pattern:  pattern,
+		segments: segments,
+	}, nil
+}
+
+// MustCompileGlob is like CompileGlob but panics if the pattern is invalid.
+func MustCompileGlob(pattern string) Glob {
+	glob, err := CompileGlob(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return glob
+}
+
+// Match reports whether the slash separated name matches the glob.
+func (glob Glob) Match(name string) bool {
+	return matchSegments(glob.segments, strings.Split(name, "/"))
+}
+
+func (glob Glob) String() string {
+	return glob.pattern
+}
+
+func matchSegments(pattern []string, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for ix := 0; ix <= len(name); ix++ {
+				if matchSegments(pattern[1:], name[ix:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+
+		if matched, _ := path.Match(pattern[0], name[0]); !matched {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// BindGlob binds a glob pattern flag, see Glob for the supported syntax.
+func BindGlob(name string, description string, optional bool, defaultValue Glob) *Binder[Glob] {
+	return &Binder[Glob]{
+		flag: &Flag[Glob]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(CompileGlob),
+		},
+	}
+}
+
+// BindGlobSlice is the repeated version of BindGlob.
+func BindGlobSlice(name string, description string, optional bool, defaultValue []Glob) *Binder[[]Glob] {
+	return &Binder[[]Glob]{
+		flag: &Flag[[]Glob]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Slice(Scalar(CompileGlob)),
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package flags
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_Regexp(t *testing.T) {
+	var include *regexp.Regexp
+	var exclude *regexp.Regexp
+
+	flags := NewSet()
+
+	BindRegexp("include", "", false, nil, false).ToValue(flags, &include)
+	BindRegexp("exclude", "", false, nil, true).ToValue(flags, &exclude)
+
+	args := []string{"--include=foo|bar", "--exclude=foo|bar"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(include.MatchString("foobar")).Equal(t, true)
+	tests.Execute(exclude.MatchString("foobar")).Equal(t, false)
+	tests.Execute(exclude.MatchString("bar")).Equal(t, true)
+}
+
+func TestFlags_RegexpInvalid(t *testing.T) {
+	var include []*regexp.Regexp
+
+	flags := NewSet()
+
+	BindRegexpSlice("include", "", false, nil, false).ToValue(flags, &include)
+
+	args := []string{"--include=ok", "--include=(unclosed"}
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+func TestFlags_Glob(t *testing.T) {
+	var globs []Glob
+
+	flags := NewSet()
+
+	BindGlobSlice("include", "", false, nil).ToValue(flags, &globs)
+
+	args := []string{"--include=**/*.go", "--include=docs/*/index.md"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+
+	tests.Execute(globs[0].Match("main.go")).Equal(t, true)
+	tests.Execute(globs[0].Match("flags/parser.go")).Equal(t, true)
+	tests.Execute(globs[0].Match("flags/parser.txt")).Equal(t, false)
+	tests.Execute(globs[1].Match("docs/api/index.md")).Equal(t, true)
+	tests.Execute(globs[1].Match("docs/api/v1/index.md")).Equal(t, false)
+}
+
+func TestFlags_GlobInvalid(t *testing.T) {
+	for _, pattern := range []string{"[a-", "src/**.go"} {
+		var glob Glob
+
+		flags := NewSet()
+
+		BindGlob("include", "", false, Glob{}).ToValue(flags, &glob)
+
+		args := []string{"--include", pattern}
+		tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+	}
+}
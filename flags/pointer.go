@@ -0,0 +1,86 @@
+package flags
+
+type aliasParserFn[T any] func(name string, args map[string][]string) (T, error)
+
+func (fn aliasParserFn[T]) Parse(name string, args map[string][]string) (T, error) {
+	return fn(name, args)
+}
+
+// BindPointer converts binder into one that targets a pointer. The pointer is left nil when the flag is not given, so
+// callers can distinguish a flag that was explicitly set to the zero value from one that was never set. The default
+// value of the original binder is ignored.
+func BindPointer[T any](binder *Binder[T]) *Binder[*T] {
+	flag := binder.flag
+
+	pointer := &Flag[*T]{
+		Name:         flag.Name,
+		Aliases:      flag.Aliases,
+		Optional:     flag.Optional,
+		Description:  flag.Description,
+		Deprecated:   flag.Deprecated,
+		Replacement:  flag.Replacement,
+		Hidden:       flag.Hidden,
+		sensitive:    flag.sensitive,
+		parseContext: flag.parseContext,
+		formatter: func(value *T) string {
+			if value == nil {
+				return ""
+			}
+			return flag.Format(*value)
+		},
+	}
+
+	if flag.parser != nil {
+		pointer.parser = Map(flag.parser, func(value T) (*T, error) {
+			return &value, nil
+		})
+	}
+
+	if flag.aliasParser != nil {
+		pointer.aliasParser = aliasParserFn[*T](func(name string, args map[string][]string) (*T, error) {
+			value, err := flag.aliasParser.Parse(name, args)
+			if err != nil {
+				return nil, err
+			}
+			return &value, nil
+		})
+	}
+
+	for _, original := range flag.validators {
+		pointer.validators = append(pointer.validators, validator[*T]{
+			check: func(value *T) error {
+				if value == nil {
+					return nil
+				}
+				return original.check(*value)
+			},
+			skipDefaults: original.skipDefaults,
+		})
+	}
+
+	if flag.acquire != nil {
+		pointer.acquire = func(value *T) (*T, error) {
+			if value == nil {
+				return nil, nil
+			}
+			acquired, err := flag.acquire(*value)
+			if err != nil {
+				return nil, err
+			}
+			return &acquired, nil
+		}
+	}
+
+	if flag.closer != nil {
+		pointer.closer = func(value *T) error {
+			if value == nil {
+				return nil
+			}
+			return flag.closer(*value)
+		}
+	}
+
+	return &Binder[*T]{
+		flag: pointer,
+	}
+}
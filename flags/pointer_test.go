@@ -0,0 +1,47 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_Pointer(t *testing.T) {
+	var retries *int
+	var force *bool
+
+	flags := NewSet()
+
+	BindPointer(BindInt("retries", "", true, 0)).ToValue(flags, &retries)
+	BindPointer(BindBoolean("force", "", true, false)).ToValue(flags, &force)
+
+	args := []string{"--retries=0", "--no-force"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(*retries).Equal(t, 0)
+	tests.Execute(*force).Equal(t, false)
+}
+
+func TestFlags_PointerUnset(t *testing.T) {
+	var retries *int
+	var force *bool
+
+	flags := NewSet()
+
+	BindPointer(BindInt("retries", "", true, 5)).ToValue(flags, &retries)
+	BindPointer(BindBoolean("force", "", true, true)).ToValue(flags, &force)
+
+	tests.Execute2E(flags.Parse([]string{})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(retries == nil).Equal(t, true)
+	tests.Execute(force == nil).Equal(t, true)
+}
+
+func TestFlags_PointerInvalid(t *testing.T) {
+	var retries *int
+
+	flags := NewSet()
+
+	BindPointer(BindInt("retries", "", true, 0)).ToValue(flags, &retries)
+
+	args := []string{"--retries=many"}
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+}
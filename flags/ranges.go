@@ -0,0 +1,215 @@
+package flags
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pasataleo/go-errors/errors"
+)
+
+// Interval is an inclusive range of integers.
+type Interval struct {
+	Start int
+	End   int
+}
+
+func (interval Interval) String() string {
+	if interval.Start == interval.End {
+		return strconv.Itoa(interval.Start)
+	}
+	return fmt.Sprintf("%d-%d", interval.Start, interval.End)
+}
+
+// IntervalSet is a sorted set of non-overlapping integer intervals, as produced by range expressions such as
+// "0-3,8,10-11".
+type IntervalSet struct {
+	intervals []Interval
+}
+
+// NewIntervalSet returns a set containing values. Consecutive values are merged into a single interval.
+func NewIntervalSet(values ...int) IntervalSet {
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+
+	var set IntervalSet
+	for _, value := range sorted {
+		if last := len(set.intervals) - 1; last >= 0 && value <= set.intervals[last].End+1 {
+			set.intervals[last].End = max(set.intervals[last].End, value)
+			continue
+		}
+		set.intervals = append(set.intervals, Interval{Start: value, End: value})
+	}
+	return set
+}
+
+// Contains reports whether value is within any interval of the set.
+func (set IntervalSet) Contains(value int) bool {
+	ix := sort.Search(len(set.intervals), func(ix int) bool {
+		return set.intervals[ix].End >= value
+	})
+	return ix < len(set.intervals) && set.intervals[ix].Start <= value
+}
+
+// Intervals returns the intervals of the set in ascending order.
+func (set IntervalSet) Intervals() []Interval {
+	return append([]Interval(nil), set.intervals...)
+}
+
+// Values returns every integer in the set in ascending order.
+func (set IntervalSet) Values() []int {
+	var values []int
+	for _, interval := range set.intervals {
+		// Stop at End rather than incrementing past it, which would overflow for intervals ending at math.MaxInt.
+		for value := interval.Start; ; value++ {
+			values = append(values, value)
+			if value == interval.End {
+				break
+			}
+		}
+	}
+	return values
+}
+
+// size returns how many integers are in the set, stopping once it exceeds limit so the count can't overflow.
+func (set IntervalSet) size(limit uint) uint {
+	var size uint
+	for _, interval := range set.intervals {
+		// Subtracting as unsigned integers gives the exact width, even for intervals wider than math.MaxInt.
+		size += uint(interval.End) - uint(interval.Start) + 1
+		if size > limit || size == 0 {
+			return limit + 1
+		}
+	}
+	return size
+}
+
+func (set IntervalSet) String() string {
+	parts := make([]string, 0, len(set.intervals))
+	for _, interval := range set.intervals {
+		parts = append(parts, interval.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseInterval parses a single "start-end" or "value" expression. Open ended ranges, "start-" or "-end", extend to the
+// supplied bounds. If lower is negative a leading minus is the sign of start instead, so "-3" is the value -3 and "-3-"
+// is the range from -3 to upper.
+func parseInterval(expression string, lower int, upper int) (Interval, error) {
+	parseBound := func(value string, fallback int) (int, error) {
+		if len(value) == 0 {
+			return fallback, nil
+		}
+		bound, err := parseSigned(value, 0)
+		return int(bound), err
+	}
+
+	// Skip over the sign of a negative start, so the separator is the first minus after it.
+	offset := 0
+	if lower < 0 && strings.HasPrefix(expression, "-") {
+		offset = 1
+	}
+
+	start, end, isRange := strings.Cut(expression[offset:], "-")
+	start = expression[:offset] + start
+	if !isRange {
+		end = start
+	}
+
+	if len(start) == 0 && len(end) == 0 {
+		return Interval{}, fmt.Errorf("invalid range %q", expression)
+	}
+
+	interval := Interval{}
+
+	var err error
+	if interval.Start, err = parseBound(start, lower); err != nil {
+		return interval, fmt.Errorf("invalid range %q: %w", expression, err)
+	}
+	if interval.End, err = parseBound(end, upper); err != nil {
+		return interval, fmt.Errorf("invalid range %q: %w", expression, err)
+	}
+
+	if interval.Start > interval.End {
+		return interval, fmt.Errorf("invalid range %q: start is after end", expression)
+	}
+	if interval.Start < lower || interval.End > upper {
+		return interval, fmt.Errorf("invalid range %q: values must be between %d and %d", expression, lower, upper)
+	}
+	return interval, nil
+}
+
+func intervalSetParser(lower int, upper int) Parser[IntervalSet] {
+	return ParserFn[IntervalSet](func(name string, args []string) (IntervalSet, error) {
+		if len(args) == 0 {
+			return IntervalSet{}, errors.Newf(nil, ErrorCodeMissingFlag, "missing flag %q", name)
+		}
+
+		var intervals []Interval
+		for _, arg := range args {
+			for _, expression := range strings.Split(arg, ",") {
+				interval, err := parseInterval(strings.TrimSpace(expression), lower, upper)
+				if err != nil {
+					return IntervalSet{}, errors.Newf(err, ErrorCodeInvalidValue, "invalid value for flag %q", name)
+				}
+				intervals = append(intervals, interval)
+			}
+		}
+
+		sort.Slice(intervals, func(i, j int) bool {
+			return intervals[i].Start < intervals[j].Start
+		})
+
+		for ix := 1; ix < len(intervals); ix++ {
+			if intervals[ix].Start <= intervals[ix-1].End {
+				err := fmt.Errorf("range %s overlaps with %s", intervals[ix], intervals[ix-1])
+				return IntervalSet{}, errors.Newf(err, ErrorCodeInvalidValue, "invalid value for flag %q", name)
+			}
+		}
+		return IntervalSet{intervals: intervals}, nil
+	})
+}
+
+// BindIntervalSet binds a flag accepting range expressions, such as "0-3,8,10-11". Every value must be between lower
+// and upper inclusive, and open ended ranges such as "8-" or "-3" extend to these bounds. Negative values are supported
+// when lower is negative, such as "-5--2" or "-5,3", in which case a leading minus is always a sign and ranges starting
+// at lower must give their start explicitly. Ranges that are reversed or overlap are rejected. The flag may be given
+// multiple times, in which case the ranges are combined.
+func BindIntervalSet(name string, description string, optional bool, defaultValue IntervalSet, lower int, upper int) *Binder[IntervalSet] {
+	return &Binder[IntervalSet]{
+		flag: &Flag[IntervalSet]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      intervalSetParser(lower, upper),
+		},
+	}
+}
+
+// maxIntRanges limits how many integers BindIntRanges expands a flag into, so a short expression such as "0-" with a
+// large upper bound can't exhaust memory.
+const maxIntRanges = 1 << 16
+
+// BindIntRanges is similar to BindIntervalSet, except the ranges are expanded into a sorted list of integers. Values
+// expanding to more than 65536 integers are rejected, use BindIntervalSet for larger ranges.
+func BindIntRanges(name string, description string, optional bool, defaultValue []int, lower int, upper int) *Binder[[]int] {
+	return &Binder[[]int]{
+		flag: &Flag[[]int]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser: Map(intervalSetParser(lower, upper), func(set IntervalSet) ([]int, error) {
+				if set.size(maxIntRanges) > maxIntRanges {
+					return nil, fmt.Errorf("ranges %s expand to more than %d values", set, maxIntRanges)
+				}
+				return set.Values(), nil
+			}),
+			formatter: func(values []int) string {
+				return NewIntervalSet(values...).String()
+			},
+		},
+	}
+}
@@ -0,0 +1,109 @@
+package flags
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_IntRanges(t *testing.T) {
+	var cpus []int
+
+	flags := NewSet()
+
+	BindIntRanges("cpus", "", false, nil, 0, 15).ToValue(flags, &cpus)
+
+	args := []string{"--cpus=8,10-11", "--cpus", "0-3"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(cpus).Equal(t, []int{0, 1, 2, 3, 8, 10, 11})
+}
+
+func TestFlags_IntervalSet(t *testing.T) {
+	var ports IntervalSet
+
+	flags := NewSet()
+
+	BindIntervalSet("ports", "", false, IntervalSet{}, 1, 65535).ToValue(flags, &ports)
+
+	args := []string{"--ports=-1023,8080,49152-"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(ports.String()).Equal(t, "1-1023,8080,49152-65535")
+	tests.Execute(ports.Contains(22)).Equal(t, true)
+	tests.Execute(ports.Contains(8080)).Equal(t, true)
+	tests.Execute(ports.Contains(8081)).Equal(t, false)
+	tests.Execute(ports.Contains(65535)).Equal(t, true)
+}
+
+func TestFlags_IntRangesNegative(t *testing.T) {
+	cases := map[string][]int{
+		"-5":     {-5},
+		"-5--3":  {-5, -4, -3},
+		"-2-1":   {-2, -1, 0, 1},
+		"8-":     {8, 9, 10},
+		"-1,3-4": {-1, 3, 4},
+	}
+
+	for arg, expected := range cases {
+		t.Run(arg, func(t *testing.T) {
+			var values []int
+
+			flags := NewSet()
+
+			BindIntRanges("r", "", false, nil, -10, 10).ToValue(flags, &values)
+
+			tests.Execute2E(flags.Parse([]string{"--r=" + arg})).NoError(t).Equal(t, make([]string, 0))
+			tests.Execute(values).Equal(t, expected)
+		})
+	}
+}
+
+func TestFlags_IntRangesMaxInt(t *testing.T) {
+	var values []int
+
+	flags := NewSet()
+
+	BindIntRanges("r", "", false, nil, 0, math.MaxInt).ToValue(flags, &values)
+
+	tests.Execute2E(flags.Parse([]string{"--r=" + strconv.Itoa(math.MaxInt-1) + "-"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(values).Equal(t, []int{math.MaxInt - 1, math.MaxInt})
+
+	// Open ended ranges up to math.MaxInt are too large to expand.
+	tests.Execute2E(flags.Parse([]string{"--r=0-"})).ErrorCode(t, ErrorCodeInvalidValue)
+	tests.Execute2E(flags.Parse([]string{"--r=0-65535"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(len(values)).Equal(t, 65536)
+	tests.Execute2E(flags.Parse([]string{"--r=0-65535,70000"})).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+func TestFlags_IntRangesInvalid(t *testing.T) {
+	cases := map[string]string{
+		"reversed":     "5-1",
+		"overlapping":  "1-5,3-7",
+		"duplicate":    "1,1",
+		"out of range": "0-16",
+		"empty":        "-",
+		"not a number": "a-b",
+	}
+
+	for name, arg := range cases {
+		t.Run(name, func(t *testing.T) {
+			var cpus []int
+
+			flags := NewSet()
+
+			BindIntRanges("cpus", "", false, nil, 0, 15).ToValue(flags, &cpus)
+
+			args := []string{"--cpus=" + arg}
+			tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+		})
+	}
+}
+
+func TestFlags_IntRangesFormat(t *testing.T) {
+	flags := NewSet()
+
+	BindIntRanges("cpus", "", true, []int{3, 0, 1, 2, 8}, 0, 15).ToFunction(flags, func(string, []int) error { return nil })
+
+	tests.Execute(flags.Flags["cpus"].FormatDefault()).Equal(t, "0-3,8")
+}
@@ -0,0 +1,79 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	_ fmt.Formatter  = (*Secret)(nil)
+	_ fmt.Stringer   = (*Secret)(nil)
+	_ slog.LogValuer = (*Secret)(nil)
+)
+
+// Secret holds a sensitive value, such as a password or token. It never prints its contents, they are only available
+// through Reveal.
+type Secret struct {
+	value []byte
+}
+
+// NewSecret returns a Secret holding value.
+func NewSecret(value string) *Secret {
+	return &Secret{
+		value: []byte(value),
+	}
+}
+
+// Reveal returns the contents of the secret.
+func (s *Secret) Reveal() string {
+	if s == nil {
+		return ""
+	}
+	return string(s.value)
+}
+
+// Wipe overwrites the contents of the secret held in memory. Strings previously returned by Reveal are unaffected.
+func (s *Secret) Wipe() {
+	if s == nil {
+		return
+	}
+	clear(s.value)
+	s.value = nil
+}
+
+// String implements fmt.Stringer and never includes the contents of the secret.
+func (s *Secret) String() string {
+	return redacted
+}
+
+// Format implements fmt.Formatter so every verb, including %#v and %x, is redacted.
+func (s *Secret) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, redacted)
+}
+
+// MarshalText implements encoding.TextMarshaler and never includes the contents of the secret.
+func (s *Secret) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+// LogValue implements slog.LogValuer and never includes the contents of the secret.
+func (s *Secret) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// BindSecret binds a flag holding a secret value. The value is redacted from errors and formatted output.
+func BindSecret(name string, description string, optional bool) *Binder[*Secret] {
+	return (&Binder[*Secret]{
+		flag: &Flag[*Secret]{
+			Name:        name,
+			Optional:    optional,
+			Description: description,
+			parser: Scalar(func(arg string) (*Secret, error) {
+				return NewSecret(arg), nil
+			}),
+		},
+	}).Sensitive()
+}
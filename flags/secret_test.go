@@ -0,0 +1,49 @@
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_Secret(t *testing.T) {
+	var token *Secret
+
+	flags := NewSet()
+
+	BindSecret("token", "", false).ToValue(flags, &token)
+
+	args := []string{"--token=hunter2"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(token.Reveal()).Equal(t, "hunter2")
+
+	for _, format := range []string{"%v", "%s", "%#v", "%x", "%q", "%+v"} {
+		tests.Execute(fmt.Sprintf(format, token)).Equal(t, redacted)
+	}
+	tests.Execute2E(json.Marshal(token)).NoError(t).Equal(t, []byte(`"[REDACTED]"`))
+
+	token.Wipe()
+	tests.Execute(token.Reveal()).Equal(t, "")
+}
+
+func TestFlags_SensitiveErrors(t *testing.T) {
+	var pin int
+
+	flags := NewSet()
+
+	BindInt("pin", "", false, 1234).Sensitive().ToValue(flags, &pin)
+
+	args := []string{"--pin=hunter2"}
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+	tests.Execute2E(flags.Parse(args)).Validate(t, func(err error) {
+		if strings.Contains(err.Error(), "hunter2") {
+			t.Errorf("error leaked the secret value: %q", err.Error())
+		}
+	})
+
+	tests.Execute(flags.Flags["pin"].FormatDefault()).Equal(t, redacted)
+	tests.Execute(flags.Flags["pin"].String()).Equal(t, "--pin=[REDACTED]")
+}
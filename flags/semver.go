@@ -0,0 +1,338 @@
+package flags
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Semver is a semantic version, as described by https://semver.org.
+type Semver struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      []string
+}
+
+// ParseSemver parses a semantic version such as v1.2.3-rc.1+meta. The leading "v" is optional.
+func ParseSemver(value string) (Semver, error) {
+	version, components, err := parseVersion(value)
+	if err != nil {
+		return version, err
+	}
+	if components != 3 {
+		return Semver{}, fmt.Errorf("invalid version %q: expected major.minor.patch", value)
+	}
+	return version, nil
+}
+
+// parseVersion parses a version that may be missing its minor and patch components, returning the number of
+// components that were present.
+func parseVersion(value string) (Semver, int, error) {
+	var version Semver
+
+	text := strings.TrimPrefix(value, "v")
+
+	text, build, hasBuild := strings.Cut(text, "+")
+	if hasBuild {
+		version.Build = strings.Split(build, ".")
+		for _, identifier := range version.Build {
+			if !isSemverIdentifier(identifier) {
+				return Semver{}, 0, fmt.Errorf("invalid version %q: invalid build identifier %q", value, identifier)
+			}
+		}
+	}
+
+	text, prerelease, hasPrerelease := strings.Cut(text, "-")
+	if hasPrerelease {
+		version.Prerelease = strings.Split(prerelease, ".")
+		for _, identifier := range version.Prerelease {
+			if !isSemverIdentifier(identifier) || (isNumeric(identifier) && hasLeadingZero(identifier)) {
+				return Semver{}, 0, fmt.Errorf("invalid version %q: invalid prerelease identifier %q", value, identifier)
+			}
+		}
+	}
+
+	parts := strings.Split(text, ".")
+	if len(parts) > 3 {
+		return Semver{}, 0, fmt.Errorf("invalid version %q: too many components", value)
+	}
+
+	numbers := []*uint64{&version.Major, &version.Minor, &version.Patch}
+	for ix, part := range parts {
+		if !isNumeric(part) || hasLeadingZero(part) {
+			return Semver{}, 0, fmt.Errorf("invalid version %q: invalid component %q", value, part)
+		}
+
+		number, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return Semver{}, 0, fmt.Errorf("invalid version %q: %w", value, err)
+		}
+		*numbers[ix] = number
+	}
+
+	if len(parts) < 3 && (hasPrerelease || hasBuild) {
+		return Semver{}, 0, fmt.Errorf("invalid version %q: expected major.minor.patch", value)
+	}
+	return version, len(parts), nil
+}
+
+func isNumeric(value string) bool {
+	if len(value) == 0 {
+		return false
+	}
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func hasLeadingZero(value string) bool {
+	return len(value) > 1 && value[0] == '0'
+}
+
+func isSemverIdentifier(value string) bool {
+	if len(value) == 0 {
+		return false
+	}
+	for _, c := range value {
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare returns -1, 0 or +1 depending on whether version has lower, equal or higher precedence than other. Build
+// metadata is ignored.
+func (version Semver) Compare(other Semver) int {
+	if c := cmp.Compare(version.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(version.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(version.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	// A version without a prerelease has higher precedence than one with.
+	switch {
+	case len(version.Prerelease) == 0 && len(other.Prerelease) == 0:
+		return 0
+	case len(version.Prerelease) == 0:
+		return 1
+	case len(other.Prerelease) == 0:
+		return -1
+	}
+
+	for ix := 0; ix < len(version.Prerelease) && ix < len(other.Prerelease); ix++ {
+		left, right := version.Prerelease[ix], other.Prerelease[ix]
+		leftNumeric, rightNumeric := isNumeric(left), isNumeric(right)
+
+		switch {
+		case leftNumeric && rightNumeric:
+			// Numeric identifiers have no leading zeros, so longer numbers are always larger.
+			if c := cmp.Compare(len(left), len(right)); c != 0 {
+				return c
+			}
+			if c := strings.Compare(left, right); c != 0 {
+				return c
+			}
+		case leftNumeric:
+			return -1
+		case rightNumeric:
+			return 1
+		default:
+			if c := strings.Compare(left, right); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmp.Compare(len(version.Prerelease), len(other.Prerelease))
+}
+
+// LessThan reports whether version has lower precedence than other.
+func (version Semver) LessThan(other Semver) bool {
+	return version.Compare(other) < 0
+}
+
+func (version Semver) String() string {
+	text := fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch)
+	if len(version.Prerelease) > 0 {
+		text += "-" + strings.Join(version.Prerelease, ".")
+	}
+	if len(version.Build) > 0 {
+		text += "+" + strings.Join(version.Build, ".")
+	}
+	return text
+}
+
+// versionComparator is a single comparison, such as ">=1.2.0", within a constraint.
+type versionComparator struct {
+	operator string
+	version  Semver
+
+	// upper, if set, makes != exclude every version from version up to, but not including, upper, for partial versions
+	// such as "!=1.2".
+	upper *Semver
+}
+
+func (comparator versionComparator) check(version Semver) bool {
+	c := version.Compare(comparator.version)
+	switch comparator.operator {
+	case "=":
+		return c == 0
+	case "!=":
+		if comparator.upper != nil {
+			return c < 0 || version.Compare(*comparator.upper) >= 0
+		}
+		return c != 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
+
+// VersionConstraint is a set of version requirements such as ">=1.2, <2.0". Comparisons separated by commas must all
+// hold, and alternatives can be separated by "||". The supported operators are =, !=, >, >=, <, <=, ~ and ^, where
+// ~1.2.3 allows patch updates and ^1.2.3 allows updates that don't change the first non-zero component. Versions
+// within constraints may omit their minor and patch components, in which case they stand for every version they match,
+// so =1.2 allows 1.2.5, >1.2 requires at least 1.3.0 and <=1.2 allows anything below 1.3.0.
+type VersionConstraint struct {
+	text         string
+	alternatives [][]versionComparator
+}
+
+// ParseVersionConstraint parses a version constraint, see VersionConstraint for the syntax.
+func ParseVersionConstraint(value string) (VersionConstraint, error) {
+	constraint := VersionConstraint{
+		text: value,
+	}
+
+	for _, alternative := range strings.Split(value, "||") {
+		var comparators []versionComparator
+		for _, term := range strings.Split(alternative, ",") {
+			term = strings.TrimSpace(term)
+			if len(term) == 0 {
+				return VersionConstraint{}, fmt.Errorf("invalid version constraint %q: empty comparison", value)
+			}
+
+			expanded, err := parseVersionComparator(term)
+			if err != nil {
+				return VersionConstraint{}, fmt.Errorf("invalid version constraint %q: %w", value, err)
+			}
+			comparators = append(comparators, expanded...)
+		}
+		constraint.alternatives = append(constraint.alternatives, comparators)
+	}
+	return constraint, nil
+}
+
+func parseVersionComparator(term string) ([]versionComparator, error) {
+	operator := "="
+	for _, candidate := range []string{">=", "<=", "!=", ">", "<", "=", "~", "^"} {
+		if rest, ok := strings.CutPrefix(term, candidate); ok {
+			operator, term = candidate, strings.TrimSpace(rest)
+			break
+		}
+	}
+
+	version, components, err := parseVersion(term)
+	if err != nil {
+		return nil, err
+	}
+
+	// next is the first version after every version matched by a partial version, such as 1.3.0 for 1.2.
+	next := Semver{Major: version.Major + 1}
+	if components > 1 {
+		next = Semver{Major: version.Major, Minor: version.Minor + 1}
+	}
+
+	switch operator {
+	case "~":
+		return []versionComparator{{operator: ">=", version: version}, {operator: "<", version: next}}, nil
+	case "^":
+		upper := Semver{Major: version.Major + 1}
+		switch {
+		case version.Major == 0 && components > 1 && version.Minor > 0:
+			upper = Semver{Minor: version.Minor + 1}
+		case version.Major == 0 && components > 2 && version.Minor == 0:
+			upper = Semver{Patch: version.Patch + 1}
+		case version.Major == 0 && components > 1:
+			upper = Semver{Minor: 1}
+		}
+		return []versionComparator{{operator: ">=", version: version}, {operator: "<", version: upper}}, nil
+	}
+
+	if components < 3 {
+		switch operator {
+		case "=":
+			return []versionComparator{{operator: ">=", version: version}, {operator: "<", version: next}}, nil
+		case "!=":
+			return []versionComparator{{operator: operator, version: version, upper: &next}}, nil
+		case ">":
+			return []versionComparator{{operator: ">=", version: next}}, nil
+		case "<=":
+			return []versionComparator{{operator: "<", version: next}}, nil
+		}
+	}
+	return []versionComparator{{operator: operator, version: version}}, nil
+}
+
+// Check reports whether version satisfies the constraint.
+func (constraint VersionConstraint) Check(version Semver) bool {
+	for _, alternative := range constraint.alternatives {
+		satisfied := true
+		for _, comparator := range alternative {
+			if !comparator.check(version) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+func (constraint VersionConstraint) String() string {
+	return constraint.text
+}
+
+// BindSemver binds a semantic version flag, such as v1.2.3-rc.1+meta.
+func BindSemver(name string, description string, optional bool, defaultValue Semver) *Binder[Semver] {
+	return &Binder[Semver]{
+		flag: &Flag[Semver]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(ParseSemver),
+		},
+	}
+}
+
+// BindVersionConstraint binds a version constraint flag, such as ">=1.2, <2.0".
+func BindVersionConstraint(name string, description string, optional bool, defaultValue VersionConstraint) *Binder[VersionConstraint] {
+	return &Binder[VersionConstraint]{
+		flag: &Flag[VersionConstraint]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(ParseVersionConstraint),
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_Semver(t *testing.T) {
+	var version Semver
+
+	flags := NewSet()
+
+	BindSemver("version", "", false, Semver{}).ToValue(flags, &version)
+
+	args := []string{"--version=v1.2.3-rc.1+build.5"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(version).Equal(t, Semver{
+		Major:      1,
+		Minor:      2,
+		Patch:      3,
+		Prerelease: []string{"rc", "1"},
+		Build:      []string{"build", "5"},
+	})
+	tests.Execute(version.String()).Equal(t, "1.2.3-rc.1+build.5")
+}
+
+func TestFlags_SemverInvalid(t *testing.T) {
+	for _, arg := range []string{"1.2", "1.2.3.4", "01.2.3", "1.2.3-", "1.2.3-01", "1.2.x"} {
+		var version Semver
+
+		flags := NewSet()
+
+		BindSemver("version", "", false, Semver{}).ToValue(flags, &version)
+
+		args := []string{"--version", arg}
+		tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+	}
+}
+
+func TestFlags_SemverPrecedence(t *testing.T) {
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.10.0",
+		"2.0.0",
+	}
+
+	for ix := 1; ix < len(ordered); ix++ {
+		lower := tests.Execute2E(ParseSemver(ordered[ix-1])).NoError(t).Capture()
+		higher := tests.Execute2E(ParseSemver(ordered[ix])).NoError(t).Capture()
+		tests.Execute(lower.LessThan(higher)).Equal(t, true)
+		tests.Execute(higher.Compare(lower)).Equal(t, 1)
+	}
+}
+
+func TestFlags_VersionConstraint(t *testing.T) {
+	cases := map[string]struct {
+		satisfied   []string
+		unsatisfied []string
+	}{
+		">=1.2, <2.0":      {[]string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0"}},
+		"~1.2.3":           {[]string{"1.2.3", "1.2.9"}, []string{"1.3.0", "1.2.2"}},
+		"^1.2.3":           {[]string{"1.2.3", "1.9.0"}, []string{"2.0.0"}},
+		"^0.2.3":           {[]string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		"^0.0.3":           {[]string{"0.0.3"}, []string{"0.0.4"}},
+		"1.2.3 || >=3.0.0": {[]string{"1.2.3", "3.1.0"}, []string{"2.0.0"}},
+		"!=1.0.0":          {[]string{"1.0.1"}, []string{"1.0.0"}},
+		"=1.2":             {[]string{"1.2.0", "1.2.5"}, []string{"1.1.9", "1.3.0"}},
+		"1":                {[]string{"1.0.0", "1.9.9"}, []string{"0.9.9", "2.0.0"}},
+		"!=1.2":            {[]string{"1.1.9", "1.3.0"}, []string{"1.2.0", "1.2.5"}},
+		">1.2":             {[]string{"1.3.0"}, []string{"1.2.1", "1.2.0"}},
+		"<=1.2":            {[]string{"1.2.9", "1.0.0"}, []string{"1.3.0"}},
+		">=1.2, <1.3":      {[]string{"1.2.0", "1.2.9"}, []string{"1.1.9", "1.3.0"}},
+	}
+
+	for expression, tc := range cases {
+		t.Run(expression, func(t *testing.T) {
+			var constraint VersionConstraint
+
+			flags := NewSet()
+
+			BindVersionConstraint("requires", "", false, VersionConstraint{}).ToValue(flags, &constraint)
+
+			args := []string{"--requires", expression}
+			tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+
+			for _, version := range tc.satisfied {
+				tests.Execute(constraint.Check(tests.Execute2E(ParseSemver(version)).NoError(t).Capture())).Equal(t, true)
+			}
+			for _, version := range tc.unsatisfied {
+				tests.Execute(constraint.Check(tests.Execute2E(ParseSemver(version)).NoError(t).Capture())).Equal(t, false)
+			}
+		})
+	}
+}
+
+func TestFlags_VersionConstraintInvalid(t *testing.T) {
+	for _, arg := range []string{">=1.2,", ">=>1.2", "~v1.x"} {
+		var constraint VersionConstraint
+
+		flags := NewSet()
+
+		BindVersionConstraint("requires", "", false, VersionConstraint{}).ToValue(flags, &constraint)
+
+		args := []string{"--requires", arg}
+		tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+	}
+}
@@ -0,0 +1,46 @@
+package flags
+
+import (
+	"io"
+	"os"
+	"reflect"
+)
+
+// stdinBuffer holds everything read from a stdin source. Stdin can only be read once, so it is buffered by the Set and
+// shared between calls to Parse and Resolve, allowing a Resolve to check a command line before Parse applies it.
+type stdinBuffer struct {
+	source io.Reader
+	data   []byte
+	err    error
+	read   bool
+}
+
+// bytes reads the source the first time it is called, and returns the same result every time after.
+func (buffer *stdinBuffer) bytes() ([]byte, error) {
+	if !buffer.read {
+		buffer.data, buffer.err = io.ReadAll(buffer.source)
+		buffer.read = true
+	}
+	return buffer.data, buffer.err
+}
+
+// stdinFor returns the buffer for source, starting a new buffer if source differs from the one used previously.
+func (flags *Set) stdinFor(source io.Reader) *stdinBuffer {
+	if source == nil {
+		source = os.Stdin
+	}
+
+	// Readers that can't be compared, which is unusual, are never shared between calls.
+	if flags.stdin == nil || !reflect.TypeOf(source).Comparable() || flags.stdin.source != source {
+		flags.stdin = &stdinBuffer{source: source}
+	}
+	return flags.stdin
+}
+
+// readStdin returns the contents of stdin for the current call to Parse.
+func (ctx *parseContext) readStdin() ([]byte, error) {
+	if ctx.stdin == nil {
+		return io.ReadAll(os.Stdin)
+	}
+	return ctx.stdin.bytes()
+}
@@ -0,0 +1,294 @@
+package flags
+
+import (
+	"encoding"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pasataleo/go-errors/errors"
+)
+
+// structField holds the flag configuration read from the tags of a struct field.
+type structField struct {
+	name         string
+	short        string
+	description  string
+	optional     bool
+	defaultValue *string
+}
+
+// BindStructSafe registers a flag for every exported field of the struct opts points to. Fields are configured with
+// the following tags:
+//
+//   - flag:"name,short=n" sets the flag name, which defaults to the field name in kebab case, and an optional short
+//     alias. A name of "-" skips the field.
+//   - desc:"..." sets the description.
+//   - default:"..." sets the default value, otherwise the current value of the field is used. Defaults for slices are
+//     comma separated.
+//   - optional:"" marks the flag as optional, as does optional:"true". Any value accepted by strconv.ParseBool can be
+//     given, so optional:"false" leaves the flag required.
+//
+// Nested structs register their fields with the name of the struct field as a prefix, embedded structs don't add a
+// prefix. Fields whose pointer implements encoding.TextUnmarshaler are bound as with BindText.
+func BindStructSafe(flags *Set, opts any) error {
+	value := reflect.ValueOf(opts)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return errors.Newf(nil, ErrorCodeUnsupportedType, "expected a pointer to a struct, got %T", opts)
+	}
+	return bindStruct(flags, value.Elem(), "")
+}
+
+// BindStruct is the panicking version of BindStructSafe.
+func BindStruct(flags *Set, opts any) {
+	if err := BindStructSafe(flags, opts); err != nil {
+		panic(err)
+	}
+}
+
+func bindStruct(flags *Set, value reflect.Value, prefix string) error {
+	for ix := 0; ix < value.NumField(); ix++ {
+		field := value.Type().Field(ix)
+		target := value.Field(ix)
+
+		// Unexported embedded structs can still contribute their exported fields.
+		if !field.IsExported() && !(field.Anonymous && isNestedStruct(target)) {
+			continue
+		}
+
+		tag, tagged := field.Tag.Lookup("flag")
+		if tag == "-" {
+			continue
+		}
+
+		spec, err := parseStructField(field, tag)
+		if err != nil {
+			return err
+		}
+		spec.name = prefix + spec.name
+
+		if isNestedStruct(target) {
+			if field.Anonymous && !tagged {
+				if err := bindStruct(flags, target, prefix); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := bindStruct(flags, target, spec.name+"-"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := bindStructField(flags, target, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseStructField(field reflect.StructField, tag string) (structField, error) {
+	spec := structField{
+		description: field.Tag.Get("desc"),
+	}
+
+	parts := strings.Split(tag, ",")
+	spec.name = parts[0]
+	for _, option := range parts[1:] {
+		if short, ok := strings.CutPrefix(option, "short="); ok {
+			spec.short = short
+		}
+	}
+
+	if len(spec.name) == 0 {
+		spec.name = kebabCase(field.Name)
+	}
+
+	if defaultValue, ok := field.Tag.Lookup("default"); ok {
+		spec.defaultValue = &defaultValue
+	}
+	if optional, ok := field.Tag.Lookup("optional"); ok {
+		spec.optional = true
+		if len(optional) > 0 {
+			var err error
+			if spec.optional, err = strconv.ParseBool(optional); err != nil {
+				return spec, errors.Newf(err, ErrorCodeInvalidValue, "invalid optional tag %q for field %s", optional, field.Name)
+			}
+		}
+	}
+	return spec, nil
+}
+
+// kebabCase converts a Go identifier, such as MaxHTTPRetries, into a flag name, such as max-http-retries.
+func kebabCase(name string) string {
+	runes := []rune(name)
+
+	var builder strings.Builder
+	for ix, r := range runes {
+		if ix > 0 && unicode.IsUpper(r) {
+			previous := runes[ix-1]
+			nextIsLower := ix+1 < len(runes) && unicode.IsLower(runes[ix+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				builder.WriteRune('-')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	return builder.String()
+}
+
+func isNestedStruct(value reflect.Value) bool {
+	if value.Kind() != reflect.Struct {
+		return false
+	}
+	return !reflect.PointerTo(value.Type()).Implements(reflect.TypeFor[encoding.TextUnmarshaler]())
+}
+
+func bindStructField(flags *Set, value reflect.Value, spec structField) error {
+	switch target := value.Addr().Interface().(type) {
+	case *string:
+		return bindTagged(flags, target, BindString(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]string:
+		return bindTagged(flags, target, BindStringSlice(spec.name, spec.description, spec.optional, *target), spec)
+	case *bool:
+		return bindTagged(flags, target, BindBoolean(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]bool:
+		return bindTagged(flags, target, BindBooleanSlice(spec.name, spec.description, spec.optional, *target), spec)
+	case *int:
+		return bindTagged(flags, target, BindInt(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]int:
+		return bindTagged(flags, target, BindIntSlice(spec.name, spec.description, spec.optional, *target), spec)
+	case *int8:
+		return bindTagged(flags, target, BindInt8(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]int8:
+		return bindTagged(flags, target, BindInt8Slice(spec.name, spec.description, spec.optional, *target), spec)
+	case *int16:
+		return bindTagged(flags, target, BindInt16(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]int16:
+		return bindTagged(flags, target, BindInt16Slice(spec.name, spec.description, spec.optional, *target), spec)
+	case *int32:
+		return bindTagged(flags, target, BindInt32(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]int32:
+		return bindTagged(flags, target, BindInt32Slice(spec.name, spec.description, spec.optional, *target), spec)
+	case *int64:
+		return bindTagged(flags, target, BindInt64(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]int64:
+		return bindTagged(flags, target, BindInt64Slice(spec.name, spec.description, spec.optional, *target), spec)
+	case *uint:
+		return bindTagged(flags, target, BindUint(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]uint:
+		return bindTagged(flags, target, BindUintSlice(spec.name, spec.description, spec.optional, *target), spec)
+	case *uint8:
+		return bindTagged(flags, target, BindUint8(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]uint8:
+		return bindTagged(flags, target, BindUint8Slice(spec.name, spec.description, spec.optional, *target), spec)
+	case *uint16:
+		return bindTagged(flags, target, BindUint16(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]uint16:
+		return bindTagged(flags, target, BindUint16Slice(spec.name, spec.description, spec.optional, *target), spec)
+	case *uint32:
+		return bindTagged(flags, target, BindUint32(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]uint32:
+		return bindTagged(flags, target, BindUint32Slice(spec.name, spec.description, spec.optional, *target), spec)
+	case *uint64:
+		return bindTagged(flags, target, BindUint64(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]uint64:
+		return bindTagged(flags, target, BindUint64Slice(spec.name, spec.description, spec.optional, *target), spec)
+	case *float32:
+		return bindTagged(flags, target, BindFloat32(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]float32:
+		return bindTagged(flags, target, BindFloat32Slice(spec.name, spec.description, spec.optional, *target), spec)
+	case *float64:
+		return bindTagged(flags, target, BindFloat64(spec.name, spec.description, spec.optional, *target), spec)
+	case *[]float64:
+		return bindTagged(flags, target, BindFloat64Slice(spec.name, spec.description, spec.optional, *target), spec)
+	case **big.Int:
+		return bindTagged(flags, target, BindBigInt(spec.name, spec.description, spec.optional, *target), spec)
+	case **big.Float:
+		return bindTagged(flags, target, BindBigFloat(spec.name, spec.description, spec.optional, *target, 0), spec)
+	case encoding.TextUnmarshaler:
+		return bindTaggedText(flags, value, spec)
+	}
+
+	return errors.Newf(nil, ErrorCodeUnsupportedType, "unsupported type %s for flag %q", value.Type(), spec.name)
+}
+
+func bindTagged[T any](flags *Set, target *T, binder *Binder[T], spec structField) error {
+	if err := applyStructField(binder.flag, spec); err != nil {
+		return err
+	}
+	return binder.ToValueSafe(flags, target)
+}
+
+// bindTaggedText binds a field whose pointer implements encoding.TextUnmarshaler. The concrete type is only known at
+// runtime so the value is produced and stored with reflection.
+func bindTaggedText(flags *Set, value reflect.Value, spec structField) error {
+	parser := Scalar(func(arg string) (any, error) {
+		result := reflect.New(value.Type())
+		if err := result.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(arg)); err != nil {
+			return nil, err
+		}
+		return result.Elem().Interface(), nil
+	})
+
+	binder := BindValue[any](spec.name, spec.description, spec.optional, value.Interface(), parser)
+	binder.flag.formatter = func(v any) string {
+		pointer := reflect.New(value.Type())
+		pointer.Elem().Set(reflect.ValueOf(v))
+		if marshaler, ok := pointer.Interface().(encoding.TextMarshaler); ok {
+			if text, err := marshaler.MarshalText(); err == nil {
+				return string(text)
+			}
+		}
+		return fmt.Sprint(v)
+	}
+
+	if err := applyStructField(binder.flag, spec); err != nil {
+		return err
+	}
+
+	// The field itself is the target, rather than a TargetFn writing to it, so atomic parses can restore it.
+	binder.flag.target = value
+	return binder.setFlag(flags)
+}
+
+// applyStructField adds the short alias and the parsed default value from the struct tags to flag.
+func applyStructField[T any](flag *Flag[T], spec structField) error {
+	if len(spec.short) > 0 {
+		flag.Aliases = append(flag.Aliases, spec.short)
+	}
+
+	if spec.defaultValue == nil {
+		return nil
+	}
+
+	var args []string
+	if reflect.TypeFor[T]().Kind() == reflect.Slice {
+		if len(*spec.defaultValue) > 0 {
+			args = strings.Split(*spec.defaultValue, ",")
+		}
+	} else {
+		args = []string{*spec.defaultValue}
+	}
+
+	var value T
+	var err error
+	switch {
+	case len(args) == 0:
+		// An empty default for a slice leaves it empty.
+	case flag.parser != nil:
+		value, err = flag.parser.Parse(flag.Name, args)
+	case flag.aliasParser != nil:
+		value, err = flag.aliasParser.Parse(flag.Name, map[string][]string{flag.Name: args})
+	}
+	if err != nil {
+		return errors.Newf(err, ErrorCodeInvalidValue, "invalid default value for flag %q", flag.Name)
+	}
+
+	flag.Default = value
+	return nil
+}
@@ -0,0 +1,103 @@
+package flags
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+type structDatabase struct {
+	Host string `desc:"database host" default:"localhost" optional:""`
+	Port uint16 `desc:"database port" default:"5432" optional:""`
+}
+
+type structCommon struct {
+	Verbose bool `flag:"verbose,short=v" optional:""`
+}
+
+type structOptions struct {
+	structCommon
+
+	Name       string     `flag:"name,short=n" desc:"name of the thing"`
+	MaxRetries int        `default:"3" optional:""`
+	Tags       []string   `flag:"tag" default:"a,b" optional:""`
+	Level      slog.Level `default:"warn" optional:""`
+	Database   structDatabase
+	Ignored    string `flag:"-"`
+	internal   string
+}
+
+func TestFlags_BindStruct(t *testing.T) {
+	var opts structOptions
+
+	flags := NewSet()
+
+	BindStruct(flags, &opts)
+
+	args := []string{"-n", "hello", "-v", "--database-host=db.internal", "--level=debug"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(opts.Verbose).Equal(t, true)
+	tests.Execute(opts.Name).Equal(t, "hello")
+	tests.Execute(opts.MaxRetries).Equal(t, 3)
+	tests.Execute(opts.Tags).Equal(t, []string{"a", "b"})
+	tests.Execute(opts.Level).Equal(t, slog.LevelDebug)
+	tests.Execute(opts.Database).Equal(t, structDatabase{Host: "db.internal", Port: 5432})
+}
+
+func TestFlags_BindStructNames(t *testing.T) {
+	var opts structOptions
+
+	flags := NewSet()
+
+	BindStruct(flags, &opts)
+
+	for _, name := range []string{"verbose", "name", "max-retries", "tag", "level", "database-host", "database-port"} {
+		if _, ok := flags.Flags[name]; !ok {
+			t.Errorf("expected flag %q to be registered", name)
+		}
+	}
+	tests.Execute(len(flags.Flags)).Equal(t, 7)
+}
+
+func TestFlags_BindStructUnsupported(t *testing.T) {
+	var opts struct {
+		Channel chan int
+	}
+
+	flags := NewSet()
+
+	tests.ExecuteE(BindStructSafe(flags, &opts)).ErrorCode(t, ErrorCodeUnsupportedType)
+	tests.ExecuteE(BindStructSafe(flags, opts)).ErrorCode(t, ErrorCodeUnsupportedType)
+}
+
+func TestFlags_BindStructOptional(t *testing.T) {
+	var opts struct {
+		Name  string `optional:"false"`
+		Color string `optional:"true"`
+	}
+
+	flags := NewSet()
+
+	BindStruct(flags, &opts)
+
+	tests.Execute(flags.Flags["name"].Optional).Equal(t, false)
+	tests.Execute(flags.Flags["color"].Optional).Equal(t, true)
+	tests.Execute2E(flags.Parse(nil)).ErrorCode(t, ErrorCodeMissingFlag)
+	tests.Execute2E(flags.Parse([]string{"--name=hello"})).NoError(t).Equal(t, make([]string, 0))
+}
+
+func TestFlags_BindStructOptionalInvalid(t *testing.T) {
+	var opts struct {
+		Name string `optional:"sometimes"`
+	}
+
+	flags := NewSet()
+
+	tests.ExecuteE(BindStructSafe(flags, &opts)).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+func TestFlags_KebabCase(t *testing.T) {
+	tests.Execute(kebabCase("MaxHTTPRetries")).Equal(t, "max-http-retries")
+	tests.Execute(kebabCase("ID")).Equal(t, "id")
+}
@@ -0,0 +1,92 @@
+package flags
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestions limits how many names are suggested for an unknown flag.
+const maxSuggestions = 3
+
+// suggest returns the registered flag names and aliases closest to name, ordered from the best match. Names within a
+// small edit distance of name, or that start with it, are considered close.
+func (flags *Set) suggest(name string) []string {
+	if len(name) == 0 {
+		return nil
+	}
+
+	// Allow roughly one typo for every four characters, so short names only match near misses.
+	threshold := max(1, len(name)/4)
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	var candidates []candidate
+	consider := func(option string) {
+		distance := levenshtein(name, option)
+		if distance > threshold && !strings.HasPrefix(option, name) {
+			return
+		}
+		candidates = append(candidates, candidate{name: option, distance: distance})
+	}
+
+	for option, flag := range flags.Flags {
+		if !flag.Hidden {
+			consider(option)
+		}
+	}
+	for option, target := range flags.aliases {
+		if !flags.Flags[target].Hidden {
+			consider(option)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	var suggestions []string
+	for _, candidate := range candidates {
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+		suggestions = append(suggestions, candidate.name)
+	}
+	return suggestions
+}
+
+// levenshtein returns the number of single character insertions, deletions and substitutions needed to turn a into b.
+// Transposing two adjacent characters counts as a single edit, as it is the most common typo.
+func levenshtein(a string, b string) int {
+	left, right := []rune(a), []rune(b)
+
+	// distances[i][j] is the distance between the first i runes of left and the first j runes of right.
+	distances := make([][]int, len(left)+1)
+	for i := range distances {
+		distances[i] = make([]int, len(right)+1)
+		distances[i][0] = i
+	}
+	for j := range distances[0] {
+		distances[0][j] = j
+	}
+
+	for i := 1; i <= len(left); i++ {
+		for j := 1; j <= len(right); j++ {
+			cost := 1
+			if left[i-1] == right[j-1] {
+				cost = 0
+			}
+
+			distances[i][j] = min(distances[i-1][j]+1, distances[i][j-1]+1, distances[i-1][j-1]+cost)
+			if i > 1 && j > 1 && left[i-1] == right[j-2] && left[i-2] == right[j-1] {
+				distances[i][j] = min(distances[i][j], distances[i-2][j-2]+1)
+			}
+		}
+	}
+	return distances[len(left)][len(right)]
+}
@@ -0,0 +1,58 @@
+package flags
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_UnknownFlagSuggestions(t *testing.T) {
+	flags := NewSet()
+
+	BindBoolean("version", "", true, false).ToValue(flags, new(bool))
+	BindBoolean("verbose", "", true, false).ToValue(flags, new(bool))
+	BindString("output", "", true, "").ToValue(flags, new(string))
+
+	tests.Execute2E(flags.Parse([]string{"--verison"}, Strict())).Validate(t, func(err error) {
+		if !strings.Contains(err.Error(), `unknown flag "verison", did you mean "version"?`) {
+			t.Errorf("expected a suggestion in the error, got %q", err.Error())
+		}
+
+		parseErrs := ParseErrors(err)
+		tests.Execute(len(parseErrs)).Equal(t, 1)
+		tests.Execute(parseErrs[0].Suggestions).Equal(t, []string{"version"})
+	})
+}
+
+func TestFlags_UnknownFlagPrefixSuggestions(t *testing.T) {
+	flags := NewSet()
+
+	BindBoolean("verbose", "", true, false).ToValue(flags, new(bool))
+	BindString("output", "", true, "").ToValue(flags, new(string))
+
+	tests.Execute2E(flags.Parse([]string{"--no-verb"}, Strict())).Validate(t, func(err error) {
+		tests.Execute(ParseErrors(err)[0].Suggestions).Equal(t, []string{"no-verbose"})
+	})
+
+	tests.Execute2E(flags.Parse([]string{"--colour"}, Strict())).Validate(t, func(err error) {
+		tests.Execute(len(ParseErrors(err)[0].Suggestions)).Equal(t, 0)
+		if strings.Contains(err.Error(), "did you mean") {
+			t.Errorf("expected no suggestions, got %q", err.Error())
+		}
+	})
+}
+
+func TestFlags_Levenshtein(t *testing.T) {
+	cases := map[[2]string]int{
+		{"", "abc"}:            3,
+		{"version", "version"}: 0,
+		{"verison", "version"}: 1,
+		{"kitten", "sitting"}:  3,
+		{"output", "ouptut"}:   1,
+	}
+
+	for words, expected := range cases {
+		tests.Execute(levenshtein(words[0], words[1])).Equal(t, expected)
+	}
+}
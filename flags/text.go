@@ -0,0 +1,112 @@
+package flags
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+
+	"github.com/pasataleo/go-errors/errors"
+)
+
+// textUnmarshaler is satisfied by pointers to types that implement encoding.TextUnmarshaler.
+type textUnmarshaler[T any] interface {
+	*T
+	encoding.TextUnmarshaler
+}
+
+// boolFlag matches the optional interface the standard library uses for flag.Value types that don't need a value.
+type boolFlag interface {
+	IsBoolFlag() bool
+}
+
+func textParser[T any, PT textUnmarshaler[T]]() func(arg string) (T, error) {
+	return func(arg string) (T, error) {
+		var value T
+		if err := PT(&value).UnmarshalText([]byte(arg)); err != nil {
+			return value, err
+		}
+		return value, nil
+	}
+}
+
+func textFormatter[T any]() func(value T) string {
+	return func(value T) string {
+		if marshaler, ok := any(&value).(encoding.TextMarshaler); ok {
+			if text, err := marshaler.MarshalText(); err == nil {
+				return string(text)
+			}
+		}
+		return fmt.Sprint(value)
+	}
+}
+
+// BindText binds a flag for any type whose pointer implements encoding.TextUnmarshaler, such as slog.Level or
+// netip.Addr. If the type also implements encoding.TextMarshaler it is used to format the value back into text.
+func BindText[T any, PT textUnmarshaler[T]](name string, description string, optional bool, defaultValue T) *Binder[T] {
+	return &Binder[T]{
+		flag: &Flag[T]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Scalar(textParser[T, PT]()),
+			formatter:   textFormatter[T](),
+		},
+	}
+}
+
+// BindTextSlice is the repeated version of BindText.
+func BindTextSlice[T any, PT textUnmarshaler[T]](name string, description string, optional bool, defaultValue []T) *Binder[[]T] {
+	format := textFormatter[T]()
+	return &Binder[[]T]{
+		flag: &Flag[[]T]{
+			Name:        name,
+			Default:     defaultValue,
+			Optional:    optional,
+			Description: description,
+			parser:      Slice(Scalar(textParser[T, PT]())),
+			formatter: func(values []T) string {
+				formatted := make([]string, 0, len(values))
+				for _, value := range values {
+					formatted = append(formatted, format(value))
+				}
+				return fmt.Sprint(formatted)
+			},
+		},
+	}
+}
+
+// BindFlagValue binds a flag backed by a standard library flag.Value. newValue is called once for the default, and
+// again for every Parse the flag is given in, so parsing never modifies a value that was already returned. Every
+// occurrence of the flag is passed to the Set method of the new value, mirroring the behaviour of the flag package. Set
+// is called while parsing, including by Set.Resolve, so any side effects it has can't be deferred like target writes.
+func BindFlagValue[V flag.Value](name string, description string, optional bool, newValue func() V) *Binder[V] {
+	return &Binder[V]{
+		flag: &Flag[V]{
+			Name:        name,
+			Default:     newValue(),
+			Optional:    optional,
+			Description: description,
+			parser: ParserFn[V](func(name string, args []string) (V, error) {
+				value := newValue()
+				if len(args) == 0 {
+					return value, errors.Newf(nil, ErrorCodeMissingFlag, "missing flag %q", name)
+				}
+
+				for _, arg := range args {
+					if b, ok := any(value).(boolFlag); ok && b.IsBoolFlag() && len(arg) == 0 {
+						arg = "true"
+					}
+
+					if err := value.Set(arg); err != nil {
+						return value, errors.Newf(err, ErrorCodeInvalidValue, "invalid value for flag %q", name)
+					}
+				}
+				return value, nil
+			}),
+			formatter: func(value V) string {
+				return value.String()
+			},
+		},
+	}
+}
@@ -0,0 +1,161 @@
+package flags
+
+import (
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+type listValue []string
+
+func (l *listValue) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listValue) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func TestFlags_Text(t *testing.T) {
+	var level slog.Level
+	var addr netip.Addr
+
+	flags := NewSet()
+
+	BindText("level", "", false, slog.LevelInfo).ToValue(flags, &level)
+	BindText("addr", "", false, netip.Addr{}).ToValue(flags, &addr)
+
+	args := []string{"--level=debug", "--addr", "10.0.0.1"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(level).Equal(t, slog.LevelDebug)
+	tests.Execute(addr.String()).Equal(t, "10.0.0.1")
+}
+
+func TestFlags_TextInvalid(t *testing.T) {
+	var addr netip.Addr
+
+	flags := NewSet()
+
+	BindText("addr", "", false, netip.Addr{}).ToValue(flags, &addr)
+
+	args := []string{"--addr=not-an-address"}
+	tests.Execute2E(flags.Parse(args)).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+func TestFlags_TextFormatDefault(t *testing.T) {
+	flags := NewSet()
+
+	BindText("level", "", true, slog.LevelWarn).ToFunction(flags, func(string, slog.Level) error { return nil })
+
+	tests.Execute(flags.Flags["level"].FormatDefault()).Equal(t, "WARN")
+}
+
+func TestFlags_FlagValue(t *testing.T) {
+	var values *listValue
+
+	flags := NewSet()
+
+	BindFlagValue("value", "", false, func() *listValue { return &listValue{} }).ToValue(flags, &values)
+
+	args := []string{"--value=hello", "--value=world"}
+	tests.Execute2E(flags.Parse(args)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute([]string(*values)).Equal(t, []string{"hello", "world"})
+
+	// Every parse starts from a new value, so values never accumulate.
+	previous := values
+	tests.Execute2E(flags.Parse([]string{"--value=again"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute([]string(*values)).Equal(t, []string{"again"})
+	tests.Execute([]string(*previous)).Equal(t, []string{"hello", "world"})
+}
+
+func TestFlags_FlagValueDefault(t *testing.T) {
+	var values *listValue
+
+	flags := NewSet()
+
+	BindFlagValue("value", "", true, func() *listValue { return &listValue{"default"} }).ToValue(flags, &values)
+
+	tests.Execute(flags.Flags["value"].FormatDefault()).Equal(t, "default")
+
+	// As with the flag package, values are added to the initial state of the flag.Value.
+	tests.Execute2E(flags.Parse([]string{"--value=hello"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute([]string(*values)).Equal(t, []string{"default", "hello"})
+	tests.Execute(flags.Flags["value"].FormatDefault()).Equal(t, "default")
+}
+
+func TestFlags_FlagValueDryRun(t *testing.T) {
+	var values *listValue
+
+	flags := NewSet()
+
+	BindFlagValue("value", "", false, func() *listValue { return &listValue{} }).ToValue(flags, &values)
+
+	resolved := tests.Execute2E(flags.Resolve([]string{"--value=a"})).NoError(t).Capture()
+	tests.Execute(values == nil).Equal(t, true)
+	tests.Execute(flags.Flags["value"].FormatDefault()).Equal(t, "")
+
+	value, _ := resolved.Get("value")
+	tests.Execute([]string(*value.(*listValue))).Equal(t, []string{"a"})
+}
+
+// enumValue is a flag.Value that keeps its configuration alongside its value.
+type enumValue struct {
+	allowed []string
+	value   string
+}
+
+func (e *enumValue) String() string {
+	return e.value
+}
+
+func (e *enumValue) Set(value string) error {
+	if !slices.Contains(e.allowed, value) {
+		return fmt.Errorf("must be one of %s", strings.Join(e.allowed, ", "))
+	}
+	e.value = value
+	return nil
+}
+
+func TestFlags_FlagValueStateful(t *testing.T) {
+	var mode *enumValue
+
+	flags := NewSet()
+
+	BindFlagValue("mode", "", false, func() *enumValue { return &enumValue{allowed: []string{"a", "b"}} }).ToValue(flags, &mode)
+
+	tests.Execute2E(flags.Parse([]string{"--mode=a"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(mode.value).Equal(t, "a")
+	tests.Execute2E(flags.Parse([]string{"--mode=c"})).ErrorCode(t, ErrorCodeInvalidValue)
+}
+
+// funcValue is a flag.Value implemented by a function, similar to flag.Func.
+type funcValue func(value string) error
+
+func (f funcValue) String() string {
+	return ""
+}
+
+func (f funcValue) Set(value string) error {
+	return f(value)
+}
+
+func TestFlags_FlagValueFunc(t *testing.T) {
+	var seen []string
+	record := funcValue(func(value string) error {
+		seen = append(seen, value)
+		return nil
+	})
+
+	flags := NewSet()
+
+	BindFlagValue("seen", "", false, func() funcValue { return record }).ToValue(flags, new(funcValue))
+
+	tests.Execute2E(flags.Parse([]string{"--seen=a", "--seen=b"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(seen).Equal(t, []string{"a", "b"})
+}
@@ -0,0 +1,46 @@
+package flags
+
+import (
+	"github.com/pasataleo/go-errors/errors"
+)
+
+// Values records the outcome of a single call to Parse, so rules and validators spanning several flags can be checked
+// once every flag has been resolved.
+type Values struct {
+	// given holds the names of flags that were set on the command line, either directly or through an alias.
+	given map[string]bool
+
+	// resolved holds the value resolved for each flag, including defaults. Flags that failed to parse or validate are
+	// absent.
+	resolved map[string]any
+
+	// occurrences records where each flag given on the command line appeared, with the values of sensitive flags
+	// redacted, so rule failures can point at the arguments involved.
+	occurrences map[string]*occurrence
+}
+
+// Given reports whether the named flag was set on the command line, rather than falling back to its default.
+func (values *Values) Given(name string) bool {
+	return values.given[name]
+}
+
+// Get returns the value resolved for the named flag, which is its default if it wasn't given on the command line. It
+// returns false if the flag doesn't exist or its value was invalid.
+func (values *Values) Get(name string) (any, bool) {
+	value, ok := values.resolved[name]
+	return value, ok
+}
+
+// Validate adds validators that run once every flag has been resolved. Errors without an error code are reported
+// using ErrorCodeInvalidValue.
+func (flags *Set) Validate(validators ...func(values *Values) error) {
+	for _, validator := range validators {
+		flags.rules = append(flags.rules, func(values *Values) error {
+			err := validator(values)
+			if err == nil || errors.GetErrorCode(err) != errors.ErrorCodeUnknown {
+				return err
+			}
+			return errors.Newf(err, ErrorCodeInvalidValue, "invalid flags")
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pasataleo/go-testing/tests"
+)
+
+func TestFlags_BinderValidate(t *testing.T) {
+	var calls []string
+	target := func(name string, value string) error {
+		calls = append(calls, value)
+		return nil
+	}
+
+	flags := NewSet()
+
+	BindString("env", "", true, "dev").Validate(func(value string) error {
+		if value != "dev" && value != "prod" {
+			return fmt.Errorf("unknown environment %q", value)
+		}
+		return nil
+	}).ToFunction(flags, target)
+
+	tests.Execute2E(flags.Parse([]string{"--env=prod"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--env=qa"})).Validate(t, func(err error) {
+		if !strings.Contains(err.Error(), `unknown environment "qa"`) {
+			t.Errorf("expected the validation failure in the error, got %q", err.Error())
+		}
+	})
+
+	// The invalid value never reaches the target.
+	tests.Execute(calls).Equal(t, []string{"prod"})
+}
+
+func TestFlags_BinderValidateDefault(t *testing.T) {
+	var port int
+
+	flags := NewSet()
+
+	BindInt("port", "", true, 0).Validate(func(value int) error {
+		if value == 0 {
+			return fmt.Errorf("port must be set")
+		}
+		return nil
+	}).ToValue(flags, &port)
+
+	tests.Execute2E(flags.Parse(nil)).ErrorCode(t, ErrorCodeInvalidValue)
+	tests.Execute2E(flags.Parse([]string{"--port=8080"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute(port).Equal(t, 8080)
+}
+
+func TestFlags_BinderValidateConstrain(t *testing.T) {
+	var calls []string
+
+	flags := NewSet()
+
+	// Constrain skips the default, while Validate checks it, and both run in the order they were added.
+	BindInt("port", "", true, 0).Constrain(NonZero[int]()).Validate(func(value int) error {
+		calls = append(calls, fmt.Sprint(value))
+		return nil
+	}).ToValue(flags, new(int))
+
+	tests.Execute2E(flags.Parse(nil)).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--port=0"})).ErrorCode(t, ErrorCodeInvalidValue)
+	tests.Execute2E(flags.Resolve([]string{"--port=0"})).ErrorCode(t, ErrorCodeInvalidValue)
+	tests.Execute(calls).Equal(t, []string{"0"})
+}
+
+func TestFlags_SetValidate(t *testing.T) {
+	var min, max int
+
+	flags := NewSet()
+
+	BindInt("min", "", true, 0).ToValue(flags, &min)
+	BindInt("max", "", true, 10).ToValue(flags, &max)
+	flags.Validate(func(values *Values) error {
+		lower, _ := values.Get("min")
+		upper, _ := values.Get("max")
+		if lower.(int) > upper.(int) {
+			return fmt.Errorf("min must not exceed max")
+		}
+		return nil
+	})
+
+	tests.Execute2E(flags.Parse([]string{"--min=5"})).NoError(t).Equal(t, make([]string, 0))
+	tests.Execute2E(flags.Parse([]string{"--min=20"})).ErrorCode(t, ErrorCodeInvalidValue)
+}